@@ -0,0 +1,54 @@
+// Command ccpfake runs the in-memory fake of the netcup CCP API as a standalone server for offline development.
+//
+// Point the provider at it with the printed endpoint, e.g.
+//
+//	ccpfake -listen 127.0.0.1:8080 -zone example.com
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+)
+
+type zoneList []string
+
+func (z *zoneList) String() string {
+	return strings.Join(*z, ",")
+}
+
+func (z *zoneList) Set(value string) error {
+	*z = append(*z, value)
+	return nil
+}
+
+func main() {
+	var (
+		listen         string
+		customerNumber string
+		apiKey         string
+		apiPassword    string
+		activateAfter  int
+		zones          zoneList
+	)
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8080", "address to listen on")
+	flag.StringVar(&customerNumber, "customer-number", "12345", "customer number accepted for login")
+	flag.StringVar(&apiKey, "api-key", "apikey", "API key accepted for login")
+	flag.StringVar(&apiPassword, "api-password", "apipassword", "API password accepted for login")
+	flag.IntVar(&activateAfter, "activate-after", 0, "number of infoDnsRecords requests before changed records become active")
+	flag.Var(&zones, "zone", "zone to serve, may be repeated")
+	flag.Parse()
+
+	fake := ccpfake.New(customerNumber, apiKey, apiPassword)
+	fake.ActivateAfter = activateAfter
+	for _, zone := range zones {
+		fake.AddZone(zone)
+	}
+
+	log.Printf("serving fake CCP API for customer %s with zones [%s] at http://%s/", customerNumber, zones.String(), listen)
+	log.Fatal(http.ListenAndServe(listen, fake))
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func (c *command) zoneShow(args []string) error {
+	args, err := c.parseCommandFlags(flag.NewFlagSet("zone show", flag.ContinueOnError), args, "domain")
+	if err != nil {
+		return err
+	}
+
+	zone, err := c.client.GetDnsZone(c.ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.print(zone, func(w io.Writer) {
+		fmt.Fprintf(w, "DOMAIN\tTTL\tSERIAL\tREFRESH\tRETRY\tEXPIRE\tDNSSEC\n")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n", zone.Name, zone.TTL, zone.Serial, zone.Refresh, zone.Retry, zone.Expire, zone.DNSSecStatus)
+	})
+}
+
+func (c *command) zoneExport(args []string) error {
+	args, err := c.parseCommandFlags(flag.NewFlagSet("zone export", flag.ContinueOnError), args, "domain")
+	if err != nil {
+		return err
+	}
+
+	zoneFile, err := c.client.ExportZoneFile(c.ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(c.stdout, zoneFile)
+	return err
+}
+
+func (c *command) zoneImport(args []string) error {
+	flags := flag.NewFlagSet("zone import", flag.ContinueOnError)
+	purge := flags.Bool("purge", false, "delete all records that are not in the zone file")
+	dryRun := flags.Bool("dry-run", false, "only print the changes")
+	args, err := c.parseCommandFlags(flags, args, "domain", "file")
+	if err != nil {
+		return err
+	}
+	domainName := args[0]
+
+	in := c.stdin
+	if args[1] != "-" {
+		f, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	zoneFile, err := client.ParseZoneFile(in, domainName)
+	if err != nil {
+		return err
+	}
+	for _, warning := range zoneFile.Warnings {
+		fmt.Fprintf(c.stderr, "warning: %s\n", warning)
+	}
+
+	existing, err := c.client.GetDnsRecords(c.ctx, domainName)
+	if err != nil {
+		return err
+	}
+	changes := client.DnsRecordChanges(existing, zoneFile.Records, func(client.DnsRecord) bool { return *purge })
+	if changes == nil {
+		changes = []client.DnsRecord{}
+	}
+
+	if !*dryRun && len(changes) > 0 {
+		if _, err := c.client.UpdateDnsRecords(c.ctx, domainName, changes); err != nil {
+			return err
+		}
+	}
+
+	return c.print(changes, func(w io.Writer) {
+		fmt.Fprintf(w, "CHANGE\tID\tNAME\tTYPE\tPRIORITY\tVALUE\n")
+		for _, record := range changes {
+			change := "create"
+			if record.DeleteRecord {
+				change = "delete"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", change, record.Id, record.Hostname, record.Type, record.Priority, record.Destination)
+		}
+	})
+}
+
+func (c *command) recordsList(args []string) error {
+	args, err := c.parseCommandFlags(flag.NewFlagSet("records list", flag.ContinueOnError), args, "domain")
+	if err != nil {
+		return err
+	}
+
+	records, err := c.client.GetDnsRecords(c.ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.printRecords(records)
+}
+
+func (c *command) recordsAdd(args []string) error {
+	flags := flag.NewFlagSet("records add", flag.ContinueOnError)
+	priority := flags.String("priority", "", "priority of MX and SRV records")
+	args, err := c.parseCommandFlags(flags, args, "domain", "name", "type", "value")
+	if err != nil {
+		return err
+	}
+
+	record, err := c.client.CreateDnsRecord(c.ctx, args[0], client.NewDnsRecord{
+		Hostname:    args[1],
+		Type:        args[2],
+		Destination: args[3],
+		Priority:    *priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printRecords([]client.DnsRecord{*record})
+}
+
+func (c *command) recordsUpdate(args []string) error {
+	flags := flag.NewFlagSet("records update", flag.ContinueOnError)
+	name := flags.String("name", "", "new hostname")
+	recordType := flags.String("type", "", "new record type")
+	value := flags.String("value", "", "new destination")
+	priority := flags.String("priority", "", "new priority")
+	args, err := c.parseCommandFlags(flags, args, "domain", "id")
+	if err != nil {
+		return err
+	}
+
+	record, err := c.client.GetDnsRecordById(c.ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	// only the given flags are changed
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			record.Hostname = *name
+		case "type":
+			record.Type = *recordType
+		case "value":
+			record.Destination = *value
+		case "priority":
+			record.Priority = *priority
+		}
+	})
+
+	updated, err := c.client.UpdateDnsRecord(c.ctx, args[0], *record)
+	if err != nil {
+		return err
+	}
+
+	return c.printRecords([]client.DnsRecord{*updated})
+}
+
+func (c *command) recordsDelete(args []string) error {
+	args, err := c.parseCommandFlags(flag.NewFlagSet("records delete", flag.ContinueOnError), args, "domain", "id")
+	if err != nil {
+		return err
+	}
+
+	record, err := c.client.GetDnsRecordById(c.ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.DeleteDnsRecord(c.ctx, args[0], *record); err != nil {
+		return err
+	}
+
+	return c.printRecords([]client.DnsRecord{*record})
+}
+
+func (c *command) printRecords(records []client.DnsRecord) error {
+	if records == nil {
+		records = []client.DnsRecord{}
+	}
+	return c.print(records, func(w io.Writer) {
+		fmt.Fprintf(w, "ID\tNAME\tTYPE\tPRIORITY\tVALUE\tSTATE\n")
+		for _, record := range records {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", record.Id, record.Hostname, record.Type, record.Priority, record.Destination, record.State)
+		}
+	})
+}
+
+// print writes v as indented JSON or, for the table output format, a table written by table.
+func (c *command) print(v interface{}, table func(w io.Writer)) error {
+	if c.output == "json" {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
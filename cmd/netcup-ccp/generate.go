@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+// hclAttribute is an attribute of a generated block, values are HCL expressions.
+type hclAttribute struct {
+	name  string
+	value string
+}
+
+func (c *command) zoneGenerate(args []string) error {
+	flags := flag.NewFlagSet("zone generate", flag.ContinueOnError)
+	noImport := flags.Bool("no-import", false, "omit the import blocks, which require Terraform 1.5 or later")
+	args, err := c.parseCommandFlags(flags, args, "domain...")
+	if err != nil {
+		return err
+	}
+
+	for i, domainName := range args {
+		zone, err := c.client.GetDnsZone(c.ctx, domainName)
+		if err != nil {
+			return err
+		}
+		records, err := c.client.GetDnsRecords(c.ctx, domainName)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(c.stdout)
+		}
+		if err := writeTerraformConfig(c.stdout, domainName, *zone, records, !*noImport); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// priorityValue returns the numeric value of a record priority, 0 for empty or invalid ones.
+func priorityValue(priority string) int {
+	value, _ := strconv.Atoi(priority)
+	return value
+}
+
+// writeTerraformConfig writes a netcup-ccp_dns_zone resource for the zone settings and a netcup-ccp_dns_record
+// resource for each record, optionally with import blocks to adopt them. Resource names are derived from domain name,
+// hostname and type, so they are stable as long as the records are. Records with the same hostname and type are
+// numbered in the order of their priorities, compared numerically, and then of their destinations.
+func writeTerraformConfig(w io.Writer, domainName string, zone client.DnsZone, records []client.DnsRecord, imports bool) error {
+	zoneName := terraformName(domainName)
+	blocks := []string{
+		fmt.Sprintf("# %s", domainName),
+		hclBlock(fmt.Sprintf("resource \"netcup-ccp_dns_zone\" %q", zoneName), []hclAttribute{
+			{"name", hclString(domainName)},
+			{"ttl", hclString(zone.TTL)},
+			{"refresh", hclString(zone.Refresh)},
+			{"retry", hclString(zone.Retry)},
+			{"expire", hclString(zone.Expire)},
+			{"dns_sec_status", fmt.Sprintf("%t", zone.DNSSecStatus)},
+		}),
+	}
+	if imports {
+		blocks = append(blocks, hclImportBlock("netcup-ccp_dns_zone."+zoneName, domainName))
+	}
+
+	sorted := append([]client.DnsRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Hostname != b.Hostname {
+			return a.Hostname < b.Hostname
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if pa, pb := priorityValue(a.Priority), priorityValue(b.Priority); pa != pb {
+			return pa < pb
+		}
+		return a.Destination < b.Destination
+	})
+
+	used := map[string]int{}
+	for _, record := range sorted {
+		hostname := record.Hostname
+		switch hostname {
+		case "@":
+			hostname = "apex"
+		case "*":
+			hostname = "wildcard"
+		}
+		name := terraformName(strings.Join([]string{domainName, hostname, record.Type}, "_"))
+		used[name]++
+		if n := used[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		attributes := []hclAttribute{
+			{"domain_name", hclString(domainName)},
+			{"name", hclString(record.Hostname)},
+			{"type", hclString(record.Type)},
+			{"value", hclString(record.Destination)},
+		}
+		if record.Priority != "" && record.Priority != "0" {
+			attributes = append(attributes, hclAttribute{"priority", hclString(record.Priority)})
+		}
+		blocks = append(blocks, hclBlock(fmt.Sprintf("resource \"netcup-ccp_dns_record\" %q", name), attributes))
+		if imports {
+			blocks = append(blocks, hclImportBlock("netcup-ccp_dns_record."+name, domainName+"/"+record.Id))
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(blocks, "\n\n")+"\n")
+	return err
+}
+
+func hclImportBlock(address string, id string) string {
+	return hclBlock("import", []hclAttribute{
+		{"to", address},
+		{"id", hclString(id)},
+	})
+}
+
+// hclBlock formats a block with aligned attributes like terraform fmt.
+func hclBlock(header string, attributes []hclAttribute) string {
+	width := 0
+	for _, a := range attributes {
+		if len(a.name) > width {
+			width = len(a.name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header + " {\n")
+	for _, a := range attributes {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, a.name, a.value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// hclString quotes s as HCL string literal, escaping template sequences.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// terraformName turns s into a valid resource name of lower case letters, digits and underscores.
+func terraformName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
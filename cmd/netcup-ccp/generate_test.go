@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestWriteTerraformConfig(t *testing.T) {
+	zone := client.DnsZone{Name: "example.com", TTL: "86400", Refresh: "28800", Retry: "7200", Expire: "1209600"}
+	records := []client.DnsRecord{
+		{Id: "3", Hostname: "www", Type: "A", Priority: "0", Destination: "5.6.7.8"},
+		{Id: "1", Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"},
+		{Id: "2", Hostname: "www", Type: "A", Priority: "0", Destination: "1.2.3.4"},
+		{Id: "4", Hostname: "*", Type: "TXT", Destination: `v=spf1 "${x}"`},
+	}
+
+	var out bytes.Buffer
+	if err := writeTerraformConfig(&out, "example.com", zone, records, true); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# example.com
+
+resource "netcup-ccp_dns_zone" "example_com" {
+  name           = "example.com"
+  ttl            = "86400"
+  refresh        = "28800"
+  retry          = "7200"
+  expire         = "1209600"
+  dns_sec_status = false
+}
+
+import {
+  to = netcup-ccp_dns_zone.example_com
+  id = "example.com"
+}
+
+resource "netcup-ccp_dns_record" "example_com_wildcard_txt" {
+  domain_name = "example.com"
+  name        = "*"
+  type        = "TXT"
+  value       = "v=spf1 \"$${x}\""
+}
+
+import {
+  to = netcup-ccp_dns_record.example_com_wildcard_txt
+  id = "example.com/4"
+}
+
+resource "netcup-ccp_dns_record" "example_com_apex_mx" {
+  domain_name = "example.com"
+  name        = "@"
+  type        = "MX"
+  value       = "mail.example.com"
+  priority    = "10"
+}
+
+import {
+  to = netcup-ccp_dns_record.example_com_apex_mx
+  id = "example.com/1"
+}
+
+resource "netcup-ccp_dns_record" "example_com_www_a" {
+  domain_name = "example.com"
+  name        = "www"
+  type        = "A"
+  value       = "1.2.3.4"
+}
+
+import {
+  to = netcup-ccp_dns_record.example_com_www_a
+  id = "example.com/2"
+}
+
+resource "netcup-ccp_dns_record" "example_com_www_a_2" {
+  domain_name = "example.com"
+  name        = "www"
+  type        = "A"
+  value       = "5.6.7.8"
+}
+
+import {
+  to = netcup-ccp_dns_record.example_com_www_a_2
+  id = "example.com/3"
+}
+`
+	if out.String() != expected {
+		t.Errorf("unexpected configuration:\n%s", out.String())
+	}
+}
+
+func TestWriteTerraformConfig_priorityOrder(t *testing.T) {
+	zone := client.DnsZone{Name: "example.com", TTL: "86400"}
+	records := []client.DnsRecord{
+		{Id: "1", Hostname: "@", Type: "MX", Priority: "10", Destination: "a.example.com"},
+		{Id: "2", Hostname: "@", Type: "MX", Priority: "5", Destination: "b.example.com"},
+	}
+
+	var out bytes.Buffer
+	if err := writeTerraformConfig(&out, "example.com", zone, records, false); err != nil {
+		t.Fatal(err)
+	}
+
+	first := strings.Index(out.String(), `priority    = "5"`)
+	second := strings.Index(out.String(), `priority    = "10"`)
+	if first < 0 || second < 0 || first > second {
+		t.Errorf("records are not ordered by numeric priority:\n%s", out.String())
+	}
+}
+
+func TestTerraformName(t *testing.T) {
+	for input, expected := range map[string]string{
+		"example.com_www_A":      "example_com_www_a",
+		"1und1.de_apex_MX":       "_1und1_de_apex_mx",
+		"example.com_foo-bar_A":  "example_com_foo_bar_a",
+		"example.com__dmarc_TXT": "example_com__dmarc_txt",
+	} {
+		if actual := terraformName(input); actual != expected {
+			t.Errorf("terraformName(%q) = %q, expected %q", input, actual, expected)
+		}
+	}
+}
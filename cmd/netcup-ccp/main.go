@@ -0,0 +1,260 @@
+// Command netcup-ccp performs DNS operations with the netcup CCP API without Terraform, e.g. to list records, add a
+// TXT record for a manual ACME challenge or dump a zone:
+//
+//	netcup-ccp zone show example.com
+//	netcup-ccp zone export example.com > example.com.zone
+//	netcup-ccp zone import [-purge] [-dry-run] example.com example.com.zone
+//	netcup-ccp zone generate [-no-import] example.com example.org > zones.tf
+//	netcup-ccp records list example.com
+//	netcup-ccp records add [-priority 10] example.com _acme-challenge TXT token
+//	netcup-ccp records update [-name www] [-type A] [-value 1.2.3.4] [-priority 0] example.com 12345
+//	netcup-ccp records delete example.com 12345
+//
+// Credentials are resolved like in the provider: all of them are taken from the NETCUP_CUSTOMER_NUMBER,
+// NETCUP_CCP_API_KEY and NETCUP_CCP_API_PASSWORD environment variables and the password file if any of these is set,
+// otherwise from the credentials command or the shared credentials file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/credentials"
+)
+
+const usage = `Usage: netcup-ccp [flags] <command> [command flags] <arguments>
+
+Commands:
+  zone show <domain>                              show the zone settings
+  zone export <domain>                            print the zone as zone file
+  zone import <domain> <file>                     create the records of a zone file, "-" reads from stdin
+  zone generate <domain>...                       print Terraform configuration with import blocks for zones
+  records list <domain>                           list the records of a zone
+  records add <domain> <name> <type> <value>      create a record
+  records update <domain> <id>                    change a record
+  records delete <domain> <id>                    delete a record
+
+Flags:
+`
+
+// errUsage is returned for invalid command lines, the usage has already been printed.
+var errUsage = errors.New("invalid usage")
+
+type globalOptions struct {
+	output             string
+	endpoint           string
+	timeout            time.Duration
+	profile            string
+	credentialsFile    string
+	credentialsCommand string
+	passwordFile       string
+	verbose            bool
+}
+
+type command struct {
+	ctx    context.Context
+	client *client.CCPClient
+	output string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "netcup-ccp: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts := globalOptions{}
+	flags := flag.NewFlagSet("netcup-ccp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.output, "output", "table", "output format, table or json")
+	flags.StringVar(&opts.endpoint, "endpoint", envOrDefault("NETCUP_CCP_ENDPOINT", client.HostURL), "URL of the CCP API endpoint")
+	flags.DurationVar(&opts.timeout, "timeout", client.DefaultTimeout, "timeout of a single request")
+	flags.StringVar(&opts.profile, "profile", envOrDefault("NETCUP_PROFILE", credentials.DefaultProfile), "profile to read from the credentials file")
+	flags.StringVar(&opts.credentialsFile, "credentials-file", os.Getenv("NETCUP_CREDENTIALS_FILE"), "path of the shared credentials file (default ~/.netcup/credentials)")
+	flags.StringVar(&opts.credentialsCommand, "credentials-command", "", "command printing the credentials as JSON, split into arguments like a shell does")
+	flags.StringVar(&opts.passwordFile, "password-file", os.Getenv("NETCUP_CCP_API_PASSWORD_FILE"), "path of a file containing the API password")
+	flags.BoolVar(&opts.verbose, "verbose", false, "log every API call with its redacted request and response body to stderr")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return errUsage
+	}
+	if opts.output != "table" && opts.output != "json" {
+		fmt.Fprintf(stderr, "unknown output format %q\n", opts.output)
+		return errUsage
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return errUsage
+	}
+
+	handler, ok := commands[flags.Arg(0)+" "+flags.Arg(1)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", flags.Arg(0)+" "+flags.Arg(1))
+		flags.Usage()
+		return errUsage
+	}
+
+	ccpClient, err := newClient(ctx, opts, stderr)
+	if err != nil {
+		return err
+	}
+
+	cmd := &command{ctx: ctx, client: ccpClient, output: opts.output, stdin: stdin, stdout: stdout, stderr: stderr}
+	return handler(cmd, flags.Args()[2:])
+}
+
+var commands = map[string]func(*command, []string) error{
+	"zone show":      (*command).zoneShow,
+	"zone export":    (*command).zoneExport,
+	"zone import":    (*command).zoneImport,
+	"zone generate":  (*command).zoneGenerate,
+	"records list":   (*command).recordsList,
+	"records add":    (*command).recordsAdd,
+	"records update": (*command).recordsUpdate,
+	"records delete": (*command).recordsDelete,
+}
+
+func newClient(ctx context.Context, opts globalOptions, stderr io.Writer) (*client.CCPClient, error) {
+	command, err := splitCommandLine(opts.credentialsCommand)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials command: %w", err)
+	}
+	creds, err := credentials.Resolve(ctx, credentials.Sources{
+		Explicit: credentials.Credentials{
+			CustomerNumber: os.Getenv("NETCUP_CUSTOMER_NUMBER"),
+			APIKey:         os.Getenv("NETCUP_CCP_API_KEY"),
+			APIPassword:    os.Getenv("NETCUP_CCP_API_PASSWORD"),
+		},
+		PasswordFile: opts.passwordFile,
+		Command:      command,
+		File:         opts.credentialsFile,
+		Profile:      opts.profile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !creds.Complete() {
+		return nil, errors.New("customer number, API key and API password are required, " +
+			"set them in the environment, a password file, a credentials command or a shared credentials file")
+	}
+
+	clientOpts := []client.Option{
+		client.WithEndpoint(opts.endpoint),
+		client.WithTimeout(opts.timeout),
+		client.WithLazyLogin(),
+	}
+	if opts.verbose {
+		clientOpts = append(clientOpts, client.WithLogger(log.New(stderr, "", log.LstdFlags)))
+	}
+
+	ccpClient, err := client.NewCCPClient(creds.CustomerNumber, creds.APIKey, creds.APIPassword, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	ccpClient.UserAgent = "netcup-ccp"
+	return ccpClient, nil
+}
+
+// parseCommandFlags parses the flags of a subcommand and checks the number of remaining arguments. A last name ending
+// in "..." accepts one or more arguments.
+func (c *command) parseCommandFlags(flags *flag.FlagSet, args []string, names ...string) ([]string, error) {
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: netcup-ccp %s [flags] <%s>\n", flags.Name(), strings.Join(names, "> <"))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	variadic := len(names) > 0 && strings.HasSuffix(names[len(names)-1], "...")
+	if flags.NArg() != len(names) && !(variadic && flags.NArg() > len(names)) {
+		flags.Usage()
+		return nil, errUsage
+	}
+	return flags.Args(), nil
+}
+
+// splitCommandLine splits a command line into its program and arguments like a POSIX shell, so the arguments match the
+// list given as credentials_command of the provider. Arguments are separated by unquoted whitespace. Single quotes
+// preserve all characters, double quotes all but backslashes escaping ", \, $ and `, and an unquoted backslash
+// preserves the next character. No expansions are performed.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unterminated single quote")
+			}
+			arg.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+			inArg = true
+		case c == '"':
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) && strings.IndexByte("\"\\$`", line[i+1]) >= 0 {
+					i++
+				}
+				arg.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, errors.New("unterminated double quote")
+			}
+			inArg = true
+		case c == '\\':
+			if i+1 == len(line) {
+				return nil, errors.New("trailing backslash")
+			}
+			i++
+			arg.WriteByte(line[i])
+			inArg = true
+		default:
+			arg.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func envOrDefault(key string, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestRun(t *testing.T) {
+	for _, key := range []string{"NETCUP_CUSTOMER_NUMBER", "NETCUP_CCP_API_KEY", "NETCUP_CCP_API_PASSWORD", "NETCUP_CCP_API_PASSWORD_FILE"} {
+		if value, ok := os.LookupEnv(key); ok {
+			os.Unsetenv(key)
+			defer os.Setenv(key, value)
+		}
+	}
+
+	fake := ccpfake.New("12345", "apikey", "apipassword")
+	fake.AddZone("example.com")
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "netcup-ccp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	credentialsFile := filepath.Join(dir, "credentials")
+	if err := ioutil.WriteFile(credentialsFile, []byte("[test]\ncustomer_number = 12345\napi_key = apikey\napi_password = apipassword\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	netcupCCP := func(stdin string, args ...string) (string, error) {
+		var stdout, stderr bytes.Buffer
+		args = append([]string{"-endpoint", srv.URL, "-credentials-file", credentialsFile, "-profile", "test"}, args...)
+		err := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+		return stdout.String(), err
+	}
+
+	out, err := netcupCCP("", "records", "add", "-priority", "10", "example.com", "@", "MX", "mail.example.com")
+	if err != nil {
+		t.Fatalf("records add: %s", err)
+	}
+	if !strings.Contains(out, "MX    10        mail.example.com") {
+		t.Errorf("unexpected table output:\n%s", out)
+	}
+
+	out, err = netcupCCP("", "-output", "json", "records", "list", "example.com")
+	if err != nil {
+		t.Fatalf("records list: %s", err)
+	}
+	var records []client.DnsRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil || len(records) != 1 {
+		t.Fatalf("unexpected JSON output %q: %v", out, err)
+	}
+
+	if _, err := netcupCCP("", "records", "update", "-value", "mx.example.com", "example.com", records[0].Id); err != nil {
+		t.Fatalf("records update: %s", err)
+	}
+	if destination := fake.Records("example.com")[0].Destination; destination != "mx.example.com" {
+		t.Errorf("expected record to be updated, got destination %q", destination)
+	}
+
+	out, err = netcupCCP("", "zone", "export", "example.com")
+	if err != nil {
+		t.Fatalf("zone export: %s", err)
+	}
+	if !strings.Contains(out, "@\tIN\tMX\t10 mx.example.com.\n") {
+		t.Errorf("unexpected zone file:\n%s", out)
+	}
+
+	out, err = netcupCCP("", "zone", "generate", "-no-import", "example.com")
+	if err != nil {
+		t.Fatalf("zone generate: %s", err)
+	}
+	if !strings.Contains(out, `resource "netcup-ccp_dns_record" "example_com_apex_mx" {`) || strings.Contains(out, "import {") {
+		t.Errorf("unexpected configuration:\n%s", out)
+	}
+
+	if _, err := netcupCCP("www IN A 1.2.3.4\n", "zone", "import", "-purge", "example.com", "-"); err != nil {
+		t.Fatalf("zone import: %s", err)
+	}
+	if records := fake.Records("example.com"); len(records) != 1 || records[0].Hostname != "www" {
+		t.Errorf("expected zone to contain only the imported record, got %v", records)
+	}
+
+	if _, err := netcupCCP("", "records", "delete", "example.com", fake.Records("example.com")[0].Id); err != nil {
+		t.Fatalf("records delete: %s", err)
+	}
+	if records := fake.Records("example.com"); len(records) != 0 {
+		t.Errorf("expected zone to be empty, got %v", records)
+	}
+
+	if _, err := netcupCCP("", "records", "add", "example.com", "www"); err != errUsage {
+		t.Errorf("expected usage error for missing arguments, got %v", err)
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	for line, expected := range map[string][]string{
+		"":                                  nil,
+		"pass-netcup --json":                {"pass-netcup", "--json"},
+		"  cat\t'/path with spaces/creds' ": {"cat", "/path with spaces/creds"},
+		`helper "a \"quoted\" \\ \x" b\ c`:  {"helper", `a "quoted" \ \x`, "b c"},
+		`sh -c 'echo "{}"'`:                 {"sh", "-c", `echo "{}"`},
+		`empty ''`:                          {"empty", ""},
+	} {
+		actual, err := splitCommandLine(line)
+		if err != nil {
+			t.Errorf("splitCommandLine(%q): %s", line, err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("splitCommandLine(%q) = %q, expected %q", line, actual, expected)
+		}
+	}
+
+	for _, line := range []string{`'unterminated`, `"unterminated`, `trailing\`} {
+		if _, err := splitCommandLine(line); err == nil {
+			t.Errorf("splitCommandLine(%q) did not fail", line)
+		}
+	}
+}
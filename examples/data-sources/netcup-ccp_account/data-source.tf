@@ -0,0 +1,13 @@
+provider "netcup-ccp" {
+  alias           = "agency"
+  profile         = "agency"
+  allowed_domains = ["example.de"]
+}
+
+data "netcup-ccp_account" "agency" {
+  provider = netcup-ccp.agency
+}
+
+output "agency_domains" {
+  value = data.netcup-ccp_account.agency.domains
+}
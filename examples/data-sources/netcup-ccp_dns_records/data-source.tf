@@ -0,0 +1,7 @@
+data "netcup-ccp_dns_records" "example" {
+  domain_name = "example.de"
+}
+
+output "mx_records" {
+  value = [for record in data.netcup-ccp_dns_records.example.records : record.value if record.type == "MX"]
+}
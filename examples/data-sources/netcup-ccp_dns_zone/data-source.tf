@@ -0,0 +1,7 @@
+data "netcup-ccp_dns_zone" "example" {
+  name = "example.de"
+}
+
+output "zone_serial" {
+  value = data.netcup-ccp_dns_zone.example.serial
+}
@@ -0,0 +1,8 @@
+data "netcup-ccp_domain_auth_code" "example" {
+  domain_name = "example.de"
+}
+
+output "auth_code" {
+  value     = data.netcup-ccp_domain_auth_code.example.auth_code
+  sensitive = true
+}
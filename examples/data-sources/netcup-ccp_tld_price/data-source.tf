@@ -0,0 +1,7 @@
+data "netcup-ccp_tld_price" "de" {
+  tld = "de"
+}
+
+output "renewal_price" {
+  value = data.netcup-ccp_tld_price.de.renewal_price
+}
@@ -0,0 +1,8 @@
+data "netcup-ccp_zone_file" "example" {
+  domain_name = "example.de"
+}
+
+# terraform output -raw zone_file > example.de.zone
+output "zone_file" {
+  value = data.netcup-ccp_zone_file.example.content
+}
@@ -1,3 +1,13 @@
-provider "scaffolding" {
-  # example configuration here
-}
\ No newline at end of file
+terraform {
+  required_providers {
+    netcup-ccp = {
+      source = "rincedd/netcup-ccp"
+    }
+  }
+}
+
+provider "netcup-ccp" {
+  customer_number  = "123456"    # Netcup customer number
+  ccp_api_key      = "xxxyyyzzz" # API key for Netcup CCP
+  ccp_api_password = "secret"    # API key password
+}
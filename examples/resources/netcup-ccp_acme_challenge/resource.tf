@@ -0,0 +1,9 @@
+# Publish the DNS-01 challenge for a certificate covering example.de and *.example.de. Both names are validated
+# with TXT records at _acme-challenge.example.de, the zone is looked up from the domains of the account.
+resource "netcup-ccp_acme_challenge" "example" {
+  fqdn   = "_acme-challenge.example.de"
+  tokens = ["digest-for-example.de", "digest-for-wildcard.example.de"]
+
+  # set to wait until the authoritative nameservers, which the CA queries, serve the records
+  query_nameservers = false
+}
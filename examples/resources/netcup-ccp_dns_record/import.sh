@@ -0,0 +1,2 @@
+# DNS records are imported by domain name and record ID
+terraform import netcup-ccp_dns_record.www example.de/12345678
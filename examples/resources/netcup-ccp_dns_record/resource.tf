@@ -0,0 +1,49 @@
+resource "netcup-ccp_dns_record" "www" {
+  domain_name = "example.de"
+  name        = "www"
+  type        = "A"
+  value       = "1.2.3.4"
+}
+
+resource "netcup-ccp_dns_record" "mail" {
+  domain_name = "example.de"
+  name        = "@"
+  type        = "MX"
+  value       = "mail.example.de"
+  priority    = "10"
+}
+
+resource "netcup-ccp_dns_record" "sip" {
+  domain_name = "example.de"
+  name        = "_sip._udp"
+  type        = "SRV"
+  priority    = "10"
+
+  srv {
+    weight = 5
+    port   = 5060
+    target = "sip.example.de"
+  }
+}
+
+resource "netcup-ccp_dns_record" "caa" {
+  domain_name = "example.de"
+  name        = "@"
+  type        = "CAA"
+
+  caa {
+    flags = 0
+    tag   = "issue"
+    value = "letsencrypt.org"
+  }
+}
+
+resource "netcup-ccp_dns_record" "acme_challenge" {
+  domain_name = "example.de"
+  name        = "_acme-challenge"
+  type        = "TXT"
+  value       = "challenge-token"
+
+  # wait until the record is active before dependent resources are created
+  wait_for_propagation {}
+}
@@ -0,0 +1,5 @@
+# DNS zones are imported by domain name
+terraform import netcup-ccp_dns_zone.example example.de
+
+# Configuration with import blocks for all settings and records of existing zones can be generated with the CLI
+netcup-ccp zone generate example.de > example.de.tf
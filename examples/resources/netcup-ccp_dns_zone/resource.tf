@@ -0,0 +1,6 @@
+resource "netcup-ccp_dns_zone" "example" {
+  name = "example.de"
+  ttl  = "3600"
+
+  # refresh, retry, expire and dns_sec_status keep their current values
+}
@@ -0,0 +1,2 @@
+# Domains are imported by domain name
+terraform import netcup-ccp_domain.example example.de
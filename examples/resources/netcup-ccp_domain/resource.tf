@@ -0,0 +1,17 @@
+resource "netcup-ccp_domain" "example" {
+  domain_name  = "example-registration.de"
+  owner_handle = "1234"
+  admin_handle = "1234"
+  tech_handle  = "5678"
+
+  nameserver {
+    hostname = "ns1.example-registration.de"
+    ipv4     = "1.2.3.4"
+  }
+  nameserver {
+    hostname = "ns2.example.net"
+  }
+
+  # set to false and apply before destroying to actually cancel the domain
+  prevent_cancel = true
+}
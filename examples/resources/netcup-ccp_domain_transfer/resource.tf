@@ -0,0 +1,13 @@
+resource "netcup-ccp_domain_transfer" "example" {
+  domain_name  = "example-transfer.de"
+  auth_code    = var.auth_code
+  owner_handle = "1234"
+  admin_handle = "1234"
+  tech_handle  = "5678"
+}
+
+variable "auth_code" {
+  type      = string
+  sensitive = true
+  default   = "auth-code-from-previous-registrar"
+}
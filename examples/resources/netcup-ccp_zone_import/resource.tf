@@ -0,0 +1,11 @@
+resource "netcup-ccp_zone_import" "example" {
+  domain_name = "example.de"
+  zone_file   = <<-EOT
+    $ORIGIN example.de.
+    @    IN  A      1.2.3.4
+    @    IN  MX     10 mail
+    mail IN  A      1.2.3.5
+    www  IN  CNAME  @
+    @    IN  TXT    "v=spf1 mx -all"
+  EOT
+}
@@ -1,3 +0,0 @@
-resource "scaffolding_resource" "example" {
-  sample_attribute = "foo"
-}
\ No newline at end of file
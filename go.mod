@@ -6,5 +6,6 @@ require (
 	github.com/hashicorp/terraform-plugin-docs v0.3.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.1
 	github.com/smartystreets/goconvey v1.6.4
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 	gopkg.in/h2non/gock.v1 v1.0.16
 )
@@ -0,0 +1,318 @@
+package ccpfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var supportedRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "MX": true, "CNAME": true, "CAA": true, "SRV": true, "TXT": true,
+	"TLSA": true, "NS": true, "DS": true, "OPENPGPKEY": true, "SMIMEA": true, "SSHFP": true,
+}
+
+type (
+	// Zone holds the zone settings as exchanged with infoDnsZone and updateDnsZone.
+	Zone struct {
+		Name         string `json:"domainname"`
+		TTL          string `json:"ttl"`
+		Serial       string `json:"serial"`
+		Refresh      string `json:"refresh"`
+		Retry        string `json:"retry"`
+		Expire       string `json:"expire"`
+		DNSSecStatus bool   `json:"dnssecstatus"`
+	}
+
+	// Record is a DNS record as exchanged with infoDnsRecords and updateDnsRecords.
+	Record struct {
+		Id           string `json:"id"`
+		Hostname     string `json:"hostname"`
+		Type         string `json:"type"`
+		Priority     string `json:"priority"`
+		Destination  string `json:"destination"`
+		DeleteRecord bool   `json:"deleterecord"`
+		State        string `json:"state"`
+	}
+
+	zone struct {
+		settings Zone
+		records  []Record
+		// pendingReads counts down the infoDnsRecords requests until changed records become active
+		pendingReads int
+	}
+
+	domainParam struct {
+		DomainName string `json:"domainname"`
+	}
+
+	updateDnsZoneParam struct {
+		DomainName string `json:"domainname"`
+		DnsZone    Zone   `json:"dnszone"`
+	}
+
+	updateDnsRecordsParam struct {
+		DomainName   string `json:"domainname"`
+		DnsRecordSet struct {
+			DnsRecords []Record `json:"dnsrecords"`
+		} `json:"dnsrecordset"`
+	}
+
+	dnsRecordSet struct {
+		DnsRecords []Record `json:"dnsrecords"`
+	}
+)
+
+// AddZone adds an empty zone with netcup's default settings and returns its settings.
+func (s *Server) AddZone(domainName string) Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := newZone(domainName)
+	s.zones[domainName] = z
+	return z.settings
+}
+
+func newZone(domainName string) *zone {
+	return &zone{settings: Zone{
+		Name:    domainName,
+		TTL:     "86400",
+		Serial:  "2021010101",
+		Refresh: "28800",
+		Retry:   "7200",
+		Expire:  "1209600",
+	}}
+}
+
+// AddRecord adds a record to an existing zone, bypassing validation, and returns it with its assigned ID.
+// It can be used to set up fixtures or simulate changes made outside of the API client under test.
+func (s *Server) AddRecord(domainName string, record Record) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[domainName]
+	if !ok {
+		return Record{}, fmt.Errorf("zone %s does not exist", domainName)
+	}
+	record.Id = s.newRecordId()
+	record.State = "yes"
+	record.DeleteRecord = false
+	z.records = append(z.records, record)
+	z.bumpSerial()
+	return record, nil
+}
+
+// RemoveRecord removes a record from a zone without going through the API.
+func (s *Server) RemoveRecord(domainName string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[domainName]
+	if !ok {
+		return fmt.Errorf("zone %s does not exist", domainName)
+	}
+	for i, record := range z.records {
+		if record.Id == id {
+			z.records = append(z.records[:i], z.records[i+1:]...)
+			z.bumpSerial()
+			return nil
+		}
+	}
+	return fmt.Errorf("record %s does not exist in zone %s", id, domainName)
+}
+
+// Records returns a copy of the records of a zone.
+func (s *Server) Records(domainName string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[domainName]
+	if !ok {
+		return nil
+	}
+	return append([]Record{}, z.records...)
+}
+
+// Zone returns the settings of a zone.
+func (s *Server) Zone(domainName string) (Zone, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z, ok := s.zones[domainName]
+	if !ok {
+		return Zone{}, false
+	}
+	return z.settings, true
+}
+
+func (s *Server) infoDnsZone(param json.RawMessage) (interface{}, *apiError) {
+	z, apiErr := s.zoneFromParam(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return z.settings, nil
+}
+
+func (s *Server) updateDnsZone(param json.RawMessage) (interface{}, *apiError) {
+	p := updateDnsZoneParam{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+	z, ok := s.zones[p.DomainName]
+	if !ok {
+		return nil, domainNotFound(p.DomainName)
+	}
+
+	for name, value := range map[string]string{"ttl": p.DnsZone.TTL, "refresh": p.DnsZone.Refresh, "retry": p.DnsZone.Retry, "expire": p.DnsZone.Expire} {
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return nil, validationError(fmt.Sprintf("Invalid value %q for %s.", value, name))
+		}
+	}
+
+	z.settings.TTL = p.DnsZone.TTL
+	z.settings.Refresh = p.DnsZone.Refresh
+	z.settings.Retry = p.DnsZone.Retry
+	z.settings.Expire = p.DnsZone.Expire
+	z.settings.DNSSecStatus = p.DnsZone.DNSSecStatus
+	z.bumpSerial()
+
+	return z.settings, nil
+}
+
+func (s *Server) infoDnsRecords(param json.RawMessage) (interface{}, *apiError) {
+	z, apiErr := s.zoneFromParam(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if z.pendingReads > 0 {
+		z.pendingReads--
+	}
+	if z.pendingReads == 0 {
+		for i := range z.records {
+			z.records[i].State = "yes"
+		}
+	}
+
+	return dnsRecordSet{DnsRecords: append([]Record{}, z.records...)}, nil
+}
+
+func (s *Server) updateDnsRecords(param json.RawMessage) (interface{}, *apiError) {
+	p := updateDnsRecordsParam{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+	z, ok := s.zones[p.DomainName]
+	if !ok {
+		return nil, domainNotFound(p.DomainName)
+	}
+
+	// validate the complete set first, the API applies all changes or none
+	for _, record := range p.DnsRecordSet.DnsRecords {
+		if record.Id != "" && z.indexOf(record.Id) < 0 {
+			return nil, &apiError{StatusCodeInvalidDnsRecord, "DNS record not found.", fmt.Sprintf("DNS record with ID %s does not exist.", record.Id)}
+		}
+		if !record.DeleteRecord {
+			if apiErr := validateRecord(record); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+	}
+
+	state := "yes"
+	if s.ActivateAfter > 0 {
+		state = "unknown"
+		z.pendingReads = s.ActivateAfter
+	}
+
+	for _, record := range p.DnsRecordSet.DnsRecords {
+		record.Type = strings.ToUpper(record.Type)
+		if record.Priority == "" {
+			record.Priority = "0"
+		}
+		record.State = state
+
+		i := z.indexOf(record.Id)
+		switch {
+		case record.Id == "":
+			record.Id = s.newRecordId()
+			z.records = append(z.records, record)
+		case i < 0:
+			// already deleted earlier in the same request
+		case record.DeleteRecord:
+			z.records = append(z.records[:i], z.records[i+1:]...)
+		default:
+			z.records[i] = record
+		}
+	}
+	z.bumpSerial()
+
+	return dnsRecordSet{DnsRecords: append([]Record{}, z.records...)}, nil
+}
+
+func (s *Server) zoneFromParam(param json.RawMessage) (*zone, *apiError) {
+	p := domainParam{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+	z, ok := s.zones[p.DomainName]
+	if !ok {
+		return nil, domainNotFound(p.DomainName)
+	}
+	return z, nil
+}
+
+func (s *Server) newRecordId() string {
+	id := strconv.Itoa(s.nextRecordId)
+	s.nextRecordId++
+	return id
+}
+
+func (z *zone) indexOf(id string) int {
+	for i, record := range z.records {
+		if record.Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (z *zone) bumpSerial() {
+	serial, err := strconv.Atoi(z.settings.Serial)
+	if err != nil {
+		serial = 0
+	}
+	z.settings.Serial = strconv.Itoa(serial + 1)
+}
+
+func validateRecord(record Record) *apiError {
+	invalid := func(msg string) *apiError {
+		return &apiError{StatusCodeInvalidDnsRecord, "Invalid DNS record.", msg}
+	}
+
+	if record.Hostname == "" {
+		return invalid("Hostname must not be empty.")
+	}
+	if record.Destination == "" {
+		return invalid("Destination must not be empty.")
+	}
+	if !supportedRecordTypes[strings.ToUpper(record.Type)] {
+		types := make([]string, 0, len(supportedRecordTypes))
+		for t := range supportedRecordTypes {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return invalid(fmt.Sprintf("Unsupported record type %q, expected one of %s.", record.Type, strings.Join(types, ", ")))
+	}
+	if record.Priority != "" {
+		if _, err := strconv.Atoi(record.Priority); err != nil {
+			return invalid(fmt.Sprintf("Invalid priority %q.", record.Priority))
+		}
+	}
+	return nil
+}
+
+func domainNotFound(domainName string) *apiError {
+	return &apiError{StatusCodeDomainNotFound, "Domain not found.", fmt.Sprintf("Can not get DNS records for zone %s. Domain not found.", domainName)}
+}
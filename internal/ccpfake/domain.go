@@ -0,0 +1,212 @@
+package ccpfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Prices are the prices per top level domain returned by priceTopleveldomain.
+var Prices = map[string]TopLevelDomainPrice{
+	"de":  {TopLevelDomain: "de", PriceCreate: "5.04", PriceRenew: "5.04", PriceTransfer: "0.00", Currency: "EUR"},
+	"com": {TopLevelDomain: "com", PriceCreate: "11.99", PriceRenew: "11.99", PriceTransfer: "11.99", Currency: "EUR"},
+	"net": {TopLevelDomain: "net", PriceCreate: "13.99", PriceRenew: "13.99", PriceTransfer: "13.99", Currency: "EUR"},
+}
+
+type (
+	// Domain is a domain as returned by infoDomain.
+	Domain struct {
+		Name        string                `json:"domainname"`
+		State       string                `json:"state"`
+		Created     string                `json:"domaincreated"`
+		Expires     string                `json:"domainexpires"`
+		Contacts    map[string]string     `json:"assignedcontacts"`
+		Nameservers map[string]Nameserver `json:"nameserverentry"`
+	}
+
+	Nameserver struct {
+		Hostname string `json:"hostname"`
+		IPv4     string `json:"ipv4,omitempty"`
+		IPv6     string `json:"ipv6,omitempty"`
+	}
+
+	TopLevelDomainPrice struct {
+		TopLevelDomain string `json:"topleveldomain"`
+		PriceCreate    string `json:"pricecreate"`
+		PriceRenew     string `json:"pricerenew"`
+		PriceTransfer  string `json:"pricetransfer"`
+		Currency       string `json:"currency"`
+	}
+
+	domainRequestParam struct {
+		DomainName  string                `json:"domainname"`
+		Contacts    map[string]string     `json:"contacts"`
+		Nameservers map[string]Nameserver `json:"nameservers"`
+		AuthCode    string                `json:"authcode"`
+	}
+)
+
+// AddDomain registers a domain including an empty DNS zone without going through the API.
+func (s *Server) AddDomain(domain Domain) {
+	s.AddZone(domain.Name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if domain.State == "" {
+		domain.State = "active"
+	}
+	s.domains[domain.Name] = &domain
+}
+
+// GetDomain returns a registered domain.
+func (s *Server) GetDomain(domainName string) (Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain, ok := s.domains[domainName]
+	if !ok {
+		return Domain{}, false
+	}
+	return *domain, true
+}
+
+// AuthCode returns the auth code that getAuthcodeDomain issues for a domain.
+func AuthCode(domainName string) string {
+	return "fake-authcode-" + domainName
+}
+
+func (s *Server) createDomain(param json.RawMessage) (interface{}, *apiError) {
+	p, apiErr := parseDomainRequest(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if _, ok := s.domains[p.DomainName]; ok {
+		return nil, validationError(fmt.Sprintf("Domain %s is not available.", p.DomainName))
+	}
+
+	s.registerDomain(p)
+	return "", nil
+}
+
+func (s *Server) transferDomain(param json.RawMessage) (interface{}, *apiError) {
+	p, apiErr := parseDomainRequest(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if _, ok := s.domains[p.DomainName]; ok {
+		return nil, validationError(fmt.Sprintf("Domain %s is already managed by this account.", p.DomainName))
+	}
+	if p.AuthCode == "" {
+		return nil, validationError("Auth code must not be empty.")
+	}
+
+	s.registerDomain(p)
+	return pending{data: ""}, nil
+}
+
+func (s *Server) infoDomain(param json.RawMessage) (interface{}, *apiError) {
+	domain, apiErr := s.domainFromParam(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return domain, nil
+}
+
+func (s *Server) updateDomain(param json.RawMessage) (interface{}, *apiError) {
+	p, apiErr := parseDomainRequest(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	domain, ok := s.domains[p.DomainName]
+	if !ok {
+		return nil, domainNotFound(p.DomainName)
+	}
+
+	domain.Contacts = p.Contacts
+	domain.Nameservers = p.Nameservers
+	return "", nil
+}
+
+func (s *Server) cancelDomain(param json.RawMessage) (interface{}, *apiError) {
+	domain, apiErr := s.domainFromParam(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	delete(s.domains, domain.Name)
+	delete(s.zones, domain.Name)
+	return "", nil
+}
+
+func (s *Server) getAuthcodeDomain(param json.RawMessage) (interface{}, *apiError) {
+	domain, apiErr := s.domainFromParam(param)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return map[string]string{"authcode": AuthCode(domain.Name)}, nil
+}
+
+func (s *Server) listallDomains(json.RawMessage) (interface{}, *apiError) {
+	domains := make([]Domain, 0, len(s.domains))
+	for _, domain := range s.domains {
+		domains = append(domains, *domain)
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Name < domains[j].Name })
+	return domains, nil
+}
+
+func (s *Server) priceTopleveldomain(param json.RawMessage) (interface{}, *apiError) {
+	p := struct {
+		TopLevelDomain string `json:"topleveldomain"`
+	}{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+
+	price, ok := Prices[strings.ToLower(p.TopLevelDomain)]
+	if !ok {
+		return nil, validationError(fmt.Sprintf("Top level domain %q is not supported.", p.TopLevelDomain))
+	}
+	return price, nil
+}
+
+func (s *Server) registerDomain(p domainRequestParam) {
+	if _, ok := s.zones[p.DomainName]; !ok {
+		s.zones[p.DomainName] = newZone(p.DomainName)
+	}
+	s.domains[p.DomainName] = &Domain{
+		Name:        p.DomainName,
+		State:       "active",
+		Created:     "2021-01-01",
+		Expires:     "2022-01-01",
+		Contacts:    p.Contacts,
+		Nameservers: p.Nameservers,
+	}
+}
+
+func (s *Server) domainFromParam(param json.RawMessage) (*Domain, *apiError) {
+	p := domainParam{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+	domain, ok := s.domains[p.DomainName]
+	if !ok {
+		return nil, domainNotFound(p.DomainName)
+	}
+	return domain, nil
+}
+
+func parseDomainRequest(param json.RawMessage) (domainRequestParam, *apiError) {
+	p := domainRequestParam{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return p, validationError(err.Error())
+	}
+	for _, contact := range []string{"ownerc", "adminc", "techc"} {
+		if p.Contacts[contact] == "" {
+			return p, validationError(fmt.Sprintf("Contact %s must not be empty.", contact))
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,288 @@
+// Package ccpfake implements an in-memory fake of the netcup CCP API for tests and offline development.
+//
+// The fake supports the session handling (login, logout), DNS actions (infoDnsZone, updateDnsZone,
+// infoDnsRecords, updateDnsRecords), domain actions (createDomain, infoDomain, updateDomain, cancelDomain,
+// transferDomain, getAuthcodeDomain, priceTopleveldomain) and the message queue (poll, ackpoll) of the real API,
+// including ID assignment, zone serial bumps, asynchronous processing and the error responses returned for
+// invalid requests. Use it with httptest:
+//
+//	fake := ccpfake.New("12345", "apikey", "apipassword")
+//	fake.AddZone("example.com")
+//	srv := httptest.NewServer(fake)
+//	defer srv.Close()
+package ccpfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Status codes returned by the fake, mirroring the ones used by the CCP API.
+const (
+	StatusCodeSuccess          = 2000
+	StatusCodeInvalidSession   = 4001
+	StatusCodeUnknownAction    = 4011
+	StatusCodeValidationError  = 4013
+	StatusCodeDomainNotFound   = 5029
+	StatusCodeInvalidDnsRecord = 5030
+)
+
+type (
+	request struct {
+		Action string          `json:"action"`
+		Param  json.RawMessage `json:"param"`
+	}
+
+	response struct {
+		ServerRequestId string      `json:"serverrequestid"`
+		ClientRequestId string      `json:"clientrequestid"`
+		Action          string      `json:"action"`
+		Status          string      `json:"status"`
+		StatusCode      int         `json:"statuscode"`
+		ShortMessage    string      `json:"shortmessage"`
+		LongMessage     string      `json:"longmessage"`
+		ResponseData    interface{} `json:"responsedata"`
+	}
+
+	authParam struct {
+		CustomerNumber string `json:"customernumber"`
+		APIKey         string `json:"apikey"`
+		APIPassword    string `json:"apipassword"`
+		SessionId      string `json:"apisessionid"`
+	}
+
+	// apiError is turned into an error response by the request dispatcher.
+	apiError struct {
+		statusCode   int
+		shortMessage string
+		longMessage  string
+	}
+
+	// pending marks the result of a request that is processed asynchronously. The request is answered with status
+	// "pending" and its outcome is queued as a message for poll.
+	pending struct {
+		data interface{}
+	}
+
+	// Message is an entry of the message queue as returned by poll.
+	Message struct {
+		ApiLogId        string `json:"apilogid"`
+		ServerRequestId string `json:"serverrequestid"`
+		Action          string `json:"action"`
+		Status          string `json:"status"`
+		StatusCode      int    `json:"statuscode"`
+		ShortMessage    string `json:"shortmessage"`
+		LongMessage     string `json:"longmessage"`
+	}
+
+	handler func(s *Server, param json.RawMessage) (interface{}, *apiError)
+)
+
+var handlers = map[string]handler{
+	"login":            (*Server).login,
+	"logout":           (*Server).logout,
+	"infoDnsZone":      (*Server).infoDnsZone,
+	"updateDnsZone":    (*Server).updateDnsZone,
+	"infoDnsRecords":   (*Server).infoDnsRecords,
+	"updateDnsRecords": (*Server).updateDnsRecords,
+
+	"createDomain":        (*Server).createDomain,
+	"infoDomain":          (*Server).infoDomain,
+	"updateDomain":        (*Server).updateDomain,
+	"cancelDomain":        (*Server).cancelDomain,
+	"transferDomain":      (*Server).transferDomain,
+	"getAuthcodeDomain":   (*Server).getAuthcodeDomain,
+	"priceTopleveldomain": (*Server).priceTopleveldomain,
+	"listallDomains":      (*Server).listallDomains,
+
+	"poll":    (*Server).poll,
+	"ackpoll": (*Server).ackpoll,
+}
+
+// Server is a stateful fake of the CCP API. It implements http.Handler and answers requests on any path.
+type Server struct {
+	customerNumber string
+	apiKey         string
+	apiPassword    string
+
+	// ActivateAfter is the number of infoDnsRecords requests for a zone after which new or changed records
+	// report state "yes". Until then they report state "unknown". Zero activates records immediately.
+	ActivateAfter int
+
+	mu            sync.Mutex
+	sessions      map[string]bool
+	zones         map[string]*zone
+	domains       map[string]*Domain
+	messages      []Message
+	nextSessionId int
+	nextRecordId  int
+	nextRequestId int
+	nextLogId     int
+}
+
+// New creates a fake that accepts logins with the given credentials and holds no zones.
+func New(customerNumber, apiKey, apiPassword string) *Server {
+	return &Server{
+		customerNumber: customerNumber,
+		apiKey:         apiKey,
+		apiPassword:    apiPassword,
+		sessions:       map[string]bool{},
+		zones:          map[string]*zone{},
+		domains:        map[string]*Domain{},
+		nextSessionId:  1,
+		nextRecordId:   1000,
+		nextRequestId:  1,
+		nextLogId:      1,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	res := s.dispatch(req)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) dispatch(req request) response {
+	res := response{
+		ServerRequestId: "fake-" + strconv.Itoa(s.nextRequestId),
+		Action:          req.Action,
+	}
+	s.nextRequestId++
+
+	data, apiErr := s.handle(req)
+	if apiErr != nil {
+		res.Status = "error"
+		res.StatusCode = apiErr.statusCode
+		res.ShortMessage = apiErr.shortMessage
+		res.LongMessage = apiErr.longMessage
+		res.ResponseData = ""
+		return res
+	}
+
+	res.Status = "success"
+	res.StatusCode = StatusCodeSuccess
+	res.ShortMessage = req.Action + " successful"
+	res.ResponseData = data
+
+	if p, ok := data.(pending); ok {
+		s.messages = append(s.messages, Message{
+			ApiLogId:        strconv.Itoa(s.nextLogId),
+			ServerRequestId: res.ServerRequestId,
+			Action:          res.Action,
+			Status:          res.Status,
+			StatusCode:      res.StatusCode,
+			ShortMessage:    res.ShortMessage,
+		})
+		s.nextLogId++
+
+		res.Status = "pending"
+		res.ShortMessage = req.Action + " pending"
+		res.ResponseData = p.data
+	}
+	return res
+}
+
+// Messages returns a copy of the unacknowledged messages in the message queue.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message{}, s.messages...)
+}
+
+func (s *Server) poll(param json.RawMessage) (interface{}, *apiError) {
+	p := struct {
+		MessageCount int `json:"messagecount"`
+	}{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+
+	count := len(s.messages)
+	if p.MessageCount > 0 && p.MessageCount < count {
+		count = p.MessageCount
+	}
+	return append([]Message{}, s.messages[:count]...), nil
+}
+
+func (s *Server) ackpoll(param json.RawMessage) (interface{}, *apiError) {
+	p := struct {
+		ApiLogId string `json:"apilogid"`
+	}{}
+	if err := json.Unmarshal(param, &p); err != nil {
+		return nil, validationError(err.Error())
+	}
+
+	for i, msg := range s.messages {
+		if msg.ApiLogId == p.ApiLogId {
+			s.messages = append(s.messages[:i], s.messages[i+1:]...)
+			return "", nil
+		}
+	}
+	return nil, validationError(fmt.Sprintf("Message with log ID %s does not exist.", p.ApiLogId))
+}
+
+func (s *Server) handle(req request) (interface{}, *apiError) {
+	h, ok := handlers[req.Action]
+	if !ok {
+		return nil, &apiError{StatusCodeUnknownAction, "Unknown action.", fmt.Sprintf("Action %q is not supported.", req.Action)}
+	}
+
+	if req.Action != "login" {
+		auth := authParam{}
+		if err := json.Unmarshal(req.Param, &auth); err != nil {
+			return nil, validationError(err.Error())
+		}
+		if auth.CustomerNumber != s.customerNumber || auth.APIKey != s.apiKey || !s.sessions[auth.SessionId] {
+			return nil, &apiError{StatusCodeInvalidSession, "The session id is not valid.", "Please log in again."}
+		}
+	}
+
+	return h(s, req.Param)
+}
+
+func (s *Server) login(param json.RawMessage) (interface{}, *apiError) {
+	auth := authParam{}
+	if err := json.Unmarshal(param, &auth); err != nil {
+		return nil, validationError(err.Error())
+	}
+	if auth.CustomerNumber != s.customerNumber || auth.APIKey != s.apiKey || auth.APIPassword != s.apiPassword {
+		return nil, &apiError{StatusCodeValidationError, "Validation Error.", "Invalid customer number, API key or API password."}
+	}
+
+	sessionId := "fake-session-" + strconv.Itoa(s.nextSessionId)
+	s.nextSessionId++
+	s.sessions[sessionId] = true
+
+	return map[string]string{"apisessionid": sessionId}, nil
+}
+
+func (s *Server) logout(param json.RawMessage) (interface{}, *apiError) {
+	auth := authParam{}
+	if err := json.Unmarshal(param, &auth); err != nil {
+		return nil, validationError(err.Error())
+	}
+	delete(s.sessions, auth.SessionId)
+	return "", nil
+}
+
+func validationError(msg string) *apiError {
+	return &apiError{StatusCodeValidationError, "Validation Error.", msg}
+}
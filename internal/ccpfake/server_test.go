@@ -0,0 +1,88 @@
+package ccpfake
+
+import (
+	"encoding/json"
+	. "github.com/smartystreets/goconvey/convey"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func call(srv *httptest.Server, body string) map[string]interface{} {
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	defer res.Body.Close()
+
+	decoded := map[string]interface{}{}
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		panic(err)
+	}
+	return decoded
+}
+
+func TestServer(t *testing.T) {
+	Convey("Given a fake with one zone", t, func() {
+		fake := New("CUSTOMER_NUMBER", "API_KEY", "API_PASSWORD")
+		fake.AddZone("domain.com")
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		Convey("login fails with wrong credentials", func() {
+			res := call(srv, `{"action":"login","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apipassword":"WRONG"}}`)
+
+			So(res["status"], ShouldEqual, "error")
+			So(res["statuscode"], ShouldEqual, StatusCodeValidationError)
+		})
+
+		Convey("requests without a valid session are rejected", func() {
+			res := call(srv, `{"action":"infoDnsZone","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"INVALID","domainname":"domain.com"}}`)
+
+			So(res["status"], ShouldEqual, "error")
+			So(res["statuscode"], ShouldEqual, StatusCodeInvalidSession)
+		})
+
+		Convey("after login", func() {
+			login := call(srv, `{"action":"login","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apipassword":"API_PASSWORD"}}`)
+			sessionId := login["responsedata"].(map[string]interface{})["apisessionid"].(string)
+			auth := `"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"` + sessionId + `"`
+
+			Convey("new records get IDs assigned and bump the zone serial", func() {
+				res := call(srv, `{"action":"updateDnsRecords","param":{`+auth+`,"domainname":"domain.com","dnsrecordset":{"dnsrecords":[{"hostname":"www","type":"a","destination":"1.2.3.4"}]}}}`)
+
+				So(res["status"], ShouldEqual, "success")
+				records := fake.Records("domain.com")
+				So(records, ShouldHaveLength, 1)
+				So(records[0].Id, ShouldNotBeEmpty)
+				So(records[0].Type, ShouldEqual, "A")
+				So(records[0].Priority, ShouldEqual, "0")
+				zone, _ := fake.Zone("domain.com")
+				So(zone.Serial, ShouldEqual, "2021010102")
+			})
+
+			Convey("invalid records are rejected without applying any change", func() {
+				res := call(srv, `{"action":"updateDnsRecords","param":{`+auth+`,"domainname":"domain.com","dnsrecordset":{"dnsrecords":[{"hostname":"www","type":"A","destination":"1.2.3.4"},{"hostname":"www","type":"FOO","destination":"bar"}]}}}`)
+
+				So(res["status"], ShouldEqual, "error")
+				So(res["statuscode"], ShouldEqual, StatusCodeInvalidDnsRecord)
+				So(fake.Records("domain.com"), ShouldBeEmpty)
+			})
+
+			Convey("unknown domains are reported as not found", func() {
+				res := call(srv, `{"action":"infoDnsRecords","param":{`+auth+`,"domainname":"other.com"}}`)
+
+				So(res["status"], ShouldEqual, "error")
+				So(res["statuscode"], ShouldEqual, StatusCodeDomainNotFound)
+			})
+
+			Convey("the session is invalid after logout", func() {
+				call(srv, `{"action":"logout","param":{`+auth+`}}`)
+				res := call(srv, `{"action":"infoDnsZone","param":{`+auth+`,"domainname":"domain.com"}}`)
+
+				So(res["statuscode"], ShouldEqual, StatusCodeInvalidSession)
+			})
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Identity of the netcup account the provider manages, useful to tell provider aliases apart.",
+		ReadContext: dataSourceAccountRead,
+		Schema: map[string]*schema.Schema{
+			"customer_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Customer number of the account.",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the CCP API endpoint.",
+			},
+			"allowed_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Domains changes are restricted to, empty if all domains are allowed.",
+			},
+			"forbidden_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Domains changes are refused for.",
+			},
+			"domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of all domains of the account.",
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	accountService, _ := m.(client.AccountService)
+
+	if accountService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Netcup CCP client",
+			Detail:   "Unable to retrieve account without Netcup CCP client",
+		})
+		return diags
+	}
+
+	domains, err := accountService.ListDomains(ctx)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to retrieve account",
+			Detail:   fmt.Sprintf("Unable to retrieve domains of customer %s: %s", accountService.CustomerNumber(), err.Error()),
+		})
+		return diags
+	}
+
+	domainNames := make([]string, len(domains))
+	for i, domain := range domains {
+		domainNames[i] = domain.Name
+	}
+
+	d.SetId(accountService.CustomerNumber())
+	d.Set("customer_number", accountService.CustomerNumber())
+	d.Set("endpoint", accountService.Endpoint())
+	d.Set("allowed_domains", accountService.AllowedDomains())
+	d.Set("forbidden_domains", accountService.ForbiddenDomains())
+	d.Set("domains", domainNames)
+
+	return diags
+}
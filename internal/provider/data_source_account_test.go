@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "netcup-ccp" {
+  allowed_domains   = ["example.com", "example.de"]
+  forbidden_domains = ["www.example.com"]
+}
+
+data "netcup-ccp_account" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netcup-ccp_account.test", "id", testAccCustomerNumber),
+					resource.TestCheckResourceAttr("data.netcup-ccp_account.test", "customer_number", testAccCustomerNumber),
+					resource.TestCheckResourceAttr("data.netcup-ccp_account.test", "allowed_domains.#", "2"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_account.test", "allowed_domains.0", "example.com"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_account.test", "forbidden_domains.0", "www.example.com"),
+					resource.TestCheckResourceAttrSet("data.netcup-ccp_account.test", "domains.#"),
+				),
+			},
+		},
+	})
+}
@@ -5,16 +5,18 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/rincedd/terraform-provider-netcup-ccp/internal/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
 )
 
 func dataSourceDnsRecords() *schema.Resource {
 	return &schema.Resource{
+		Description: "DNS records of a domain.",
 		ReadContext: dataSourceDnsRecordsRead,
 		Schema: map[string]*schema.Schema{
 			"domain_name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Domain name of the zone.",
 			},
 			"records": {
 				Type:     schema.TypeList,
@@ -47,6 +49,7 @@ func dataSourceDnsRecords() *schema.Resource {
 						},
 					},
 				},
+				Description: "All records of the zone.",
 			},
 		},
 	}
@@ -56,9 +59,9 @@ func dataSourceDnsRecordsRead(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 
 	domainName := d.Get("domain_name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService, _ := m.(client.DNSService)
 
-	if ccpClient == nil {
+	if dnsService == nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Missing Netcup CCP client",
@@ -67,7 +70,7 @@ func dataSourceDnsRecordsRead(ctx context.Context, d *schema.ResourceData, m int
 		return diags
 	}
 
-	dnsRecords, err := ccpClient.GetDnsRecords(domainName)
+	dnsRecords, err := dnsService.GetDnsRecords(ctx, domainName)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestAccDataSourceDnsRecords(t *testing.T) {
+	domainName := "records.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+			testAccFake.AddRecord(domainName, ccpfake.Record{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"})
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDnsRecords(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.0.name", "@"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.0.type", "MX"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.0.priority", "10"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.0.value", "mail.example.com"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.1.name", "www"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_records.test", "records.1.state", "yes"),
+					resource.TestCheckResourceAttrPair("data.netcup-ccp_dns_records.test", "records.1.id", "netcup-ccp_dns_record.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDnsRecords(domainName string) string {
+	return fmt.Sprintf(`
+resource "netcup-ccp_dns_record" "test" {
+  domain_name = %[1]q
+  name        = "www"
+  type        = "A"
+  value       = "1.2.3.4"
+}
+
+data "netcup-ccp_dns_records" "test" {
+  domain_name = %[1]q
+  depends_on  = [netcup-ccp_dns_record.test]
+}
+`, domainName)
+}
+
+func TestDataSourceDnsRecordsRead(t *testing.T) {
+	dns := newMemoryDNS()
+	dns.addZone("example.com",
+		client.DnsRecord{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"},
+		client.DnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"},
+	)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDnsRecords().Schema, map[string]interface{}{"domain_name": "example.com"})
+	if diags := dataSourceDnsRecordsRead(context.Background(), d, dns); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if d.Id() != "example.com" {
+		t.Errorf("ID is %q, expected example.com", d.Id())
+	}
+	if count := d.Get("records.#").(int); count != 2 {
+		t.Fatalf("read %d records, expected 2", count)
+	}
+	if value := d.Get("records.1.value").(string); value != "1.2.3.4" {
+		t.Errorf("second record has value %q, expected 1.2.3.4", value)
+	}
+}
+
+func TestDataSourceDnsRecordsRead_unknownZone(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceDnsRecords().Schema, map[string]interface{}{"domain_name": "example.com"})
+	if diags := dataSourceDnsRecordsRead(context.Background(), d, newMemoryDNS()); !diags.HasError() {
+		t.Error("expected an error for an unknown zone")
+	}
+}
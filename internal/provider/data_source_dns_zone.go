@@ -5,40 +5,48 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/rincedd/terraform-provider-netcup-ccp/internal/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
 )
 
 func dataSourceDnsZone() *schema.Resource {
 	return &schema.Resource{
+		Description: "DNS zone settings of a domain.",
 		ReadContext: dataSourceDnsZoneRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Domain name of the zone.",
 			},
 			"ttl": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default TTL of the zone records in seconds.",
 			},
 			"serial": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Serial number of the zone.",
 			},
 			"refresh": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SOA refresh interval in seconds.",
 			},
 			"retry": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SOA retry interval in seconds.",
 			},
 			"expire": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SOA expire time in seconds.",
 			},
 			"dns_sec_status": {
-				Type:     schema.TypeBool,
-				Computed: true,
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether DNSSEC is enabled for the zone.",
 			},
 		},
 	}
@@ -48,9 +56,9 @@ func dataSourceDnsZoneRead(ctx context.Context, d *schema.ResourceData, m interf
 	var diags diag.Diagnostics
 
 	domainName := d.Get("name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService, _ := m.(client.DNSService)
 
-	if ccpClient == nil {
+	if dnsService == nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Missing Netcup CCP client",
@@ -59,7 +67,7 @@ func dataSourceDnsZoneRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diags
 	}
 
-	dnsZone, err := ccpClient.GetDnsZone(domainName)
+	dnsZone, err := dnsService.GetDnsZone(ctx, domainName)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
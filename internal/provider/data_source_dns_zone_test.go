@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceDnsZone(t *testing.T) {
+	domainName := "zone.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "netcup-ccp_dns_zone" "test" {
+  name = %q
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "id", domainName),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "ttl", "86400"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "refresh", "28800"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "retry", "7200"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "expire", "1209600"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_dns_zone.test", "dns_sec_status", "false"),
+					resource.TestCheckResourceAttrSet("data.netcup-ccp_dns_zone.test", "serial"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDnsZoneRead(t *testing.T) {
+	dns := newMemoryDNS()
+	dns.addZone("example.com")
+
+	d := schema.TestResourceDataRaw(t, dataSourceDnsZone().Schema, map[string]interface{}{"name": "example.com"})
+	if diags := dataSourceDnsZoneRead(context.Background(), d, dns); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if d.Id() != "example.com" {
+		t.Errorf("ID is %q, expected example.com", d.Id())
+	}
+	if ttl := d.Get("ttl").(string); ttl != "86400" {
+		t.Errorf("TTL is %q, expected 86400", ttl)
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func dataSourceDomainAuthCode() *schema.Resource {
+	return &schema.Resource{
+		Description: "Auth code required to transfer a domain away from netcup.",
+		ReadContext: dataSourceDomainAuthCodeRead,
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"auth_code": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceDomainAuthCodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainName := d.Get("domain_name").(string)
+	domainService, _ := m.(client.DomainService)
+
+	if domainService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Netcup CCP client",
+			Detail:   "Unable to retrieve auth code without Netcup CCP client",
+		})
+		return diags
+	}
+
+	authCode, err := domainService.GetAuthCode(ctx, domainName)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to retrieve auth code",
+			Detail:   fmt.Sprintf("Unable to retrieve auth code for %s: %s", domainName, err.Error()),
+		})
+		return diags
+	}
+
+	d.SetId(domainName)
+	d.Set("auth_code", authCode)
+
+	return diags
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+)
+
+func TestAccDataSourceDomainAuthCode(t *testing.T) {
+	domainName := "authcode-test.de"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddDomain(ccpfake.Domain{Name: domainName})
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "netcup-ccp_domain_auth_code" "test" {
+  domain_name = %q
+}
+`, domainName),
+				Check: resource.TestCheckResourceAttr("data.netcup-ccp_domain_auth_code.test", "auth_code", ccpfake.AuthCode(domainName)),
+			},
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func dataSourceTldPrice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Registration, renewal and transfer prices of a top level domain.",
+		ReadContext: dataSourceTldPriceRead,
+		Schema: map[string]*schema.Schema{
+			"tld": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Top level domain without leading dot, e.g. `de`.",
+			},
+			"registration_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"renewal_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"transfer_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTldPriceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tld := d.Get("tld").(string)
+	domainService, _ := m.(client.DomainService)
+
+	if domainService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Netcup CCP client",
+			Detail:   "Unable to retrieve TLD prices without Netcup CCP client",
+		})
+		return diags
+	}
+
+	price, err := domainService.GetTopLevelDomainPrice(ctx, tld)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to retrieve TLD prices",
+			Detail:   fmt.Sprintf("Unable to retrieve prices for TLD %s: %s", tld, err.Error()),
+		})
+		return diags
+	}
+
+	d.SetId(tld)
+	d.Set("registration_price", float64(price.PriceCreate))
+	d.Set("renewal_price", float64(price.PriceRenew))
+	d.Set("transfer_price", float64(price.PriceTransfer))
+	d.Set("currency", price.Currency)
+
+	return diags
+}
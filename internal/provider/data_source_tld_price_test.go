@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTldPrice(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "netcup-ccp_tld_price" "test" {
+  tld = "de"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netcup-ccp_tld_price.test", "registration_price", "5.04"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_tld_price.test", "transfer_price", "0"),
+					resource.TestCheckResourceAttr("data.netcup-ccp_tld_price.test", "currency", "EUR"),
+				),
+			},
+		},
+	})
+}
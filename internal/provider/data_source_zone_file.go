@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func dataSourceZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Description: "DNS zone of a domain exported as RFC 1035 zone file, e.g. for backups or to compare zones.",
+		ReadContext: dataSourceZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Domain name of the zone.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Zone file in BIND format, including SOA and NS records. Records are sorted to produce stable output.",
+			},
+		},
+	}
+}
+
+func dataSourceZoneFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainName := d.Get("domain_name").(string)
+	dnsService, _ := m.(client.DNSService)
+
+	if dnsService == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Netcup CCP client",
+			Detail:   "Unable to export zone file without Netcup CCP client",
+		})
+		return diags
+	}
+
+	content, err := client.ExportDnsZone(ctx, dnsService, domainName)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to export zone file",
+			Detail:   fmt.Sprintf("Unable to export zone file for domain %s: %s", domainName, err.Error()),
+		})
+		return diags
+	}
+
+	d.SetId(domainName)
+	d.Set("content", content)
+
+	return diags
+}
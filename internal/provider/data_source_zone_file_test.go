@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+)
+
+func TestAccDataSourceZoneFile(t *testing.T) {
+	domainName := "zonefile.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+			if _, err := testAccFake.AddRecord(domainName, ccpfake.Record{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"}); err != nil {
+				t.Fatal(err)
+			}
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "netcup-ccp_zone_file" "test" {
+  domain_name = %q
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netcup-ccp_zone_file.test", "id", domainName),
+					resource.TestMatchResourceAttr("data.netcup-ccp_zone_file.test", "content", regexp.MustCompile(`^\$ORIGIN zonefile\.example\.com\.\n`)),
+					resource.TestMatchResourceAttr("data.netcup-ccp_zone_file.test", "content", regexp.MustCompile(`\n@\tIN\tMX\t10 mail\.example\.com\.\n`)),
+				),
+			},
+		},
+	})
+}
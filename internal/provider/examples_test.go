@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+)
+
+// TestAccExamples applies the examples used in the documentation against the fake CCP API.
+func TestAccExamples(t *testing.T) {
+	examples := []struct {
+		path string
+		// planOnly is set for examples that cannot be destroyed after applying them
+		planOnly bool
+	}{
+		{path: "resources/netcup-ccp_acme_challenge/resource.tf"},
+		{path: "resources/netcup-ccp_dns_record/resource.tf"},
+		{path: "resources/netcup-ccp_dns_zone/resource.tf"},
+		{path: "resources/netcup-ccp_domain/resource.tf", planOnly: true},
+		{path: "resources/netcup-ccp_domain_transfer/resource.tf"},
+		{path: "resources/netcup-ccp_zone_import/resource.tf"},
+		{path: "data-sources/netcup-ccp_account/data-source.tf"},
+		{path: "data-sources/netcup-ccp_dns_zone/data-source.tf"},
+		{path: "data-sources/netcup-ccp_dns_records/data-source.tf"},
+		{path: "data-sources/netcup-ccp_domain_auth_code/data-source.tf"},
+		{path: "data-sources/netcup-ccp_tld_price/data-source.tf"},
+		{path: "data-sources/netcup-ccp_zone_file/data-source.tf"},
+	}
+
+	for _, example := range examples {
+		example := example
+		t.Run(example.path, func(t *testing.T) {
+			config, err := ioutil.ReadFile(filepath.Join("..", "..", "examples", example.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck: func() {
+					testAccPreCheck(t)
+					if _, ok := testAccFake.GetDomain("example.de"); !ok {
+						testAccFake.AddDomain(ccpfake.Domain{Name: "example.de"})
+					}
+				},
+				ProviderFactories: providerFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:             string(config),
+						PlanOnly:           example.planOnly,
+						ExpectNonEmptyPlan: example.planOnly,
+					},
+				},
+			})
+		})
+	}
+}
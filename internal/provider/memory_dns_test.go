@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+// memoryDNS is an in-memory client.DNSService for unit tests of resources and data sources. Changed records are
+// active immediately.
+type memoryDNS struct {
+	mu          sync.Mutex
+	zones       map[string]*client.DnsZone
+	zoneRecords map[string][]client.DnsRecord
+	nextId      int
+	ttls        *zoneTTLPlan
+}
+
+var (
+	_ client.DNSService = (*memoryDNS)(nil)
+	_ zoneTTLPlanner    = (*memoryDNS)(nil)
+)
+
+func newMemoryDNS() *memoryDNS {
+	return &memoryDNS{
+		zones:       map[string]*client.DnsZone{},
+		zoneRecords: map[string][]client.DnsRecord{},
+		nextId:      1,
+		ttls:        newZoneTTLPlan(),
+	}
+}
+
+func (m *memoryDNS) zoneTTLPlan() *zoneTTLPlan {
+	return m.ttls
+}
+
+// addZone adds a zone with netcup's default settings and the given records, which get IDs assigned.
+func (m *memoryDNS) addZone(domainName string, records ...client.DnsRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.zones[domainName] = &client.DnsZone{
+		Name:    domainName,
+		TTL:     "86400",
+		Serial:  "2021010101",
+		Refresh: "28800",
+		Retry:   "7200",
+		Expire:  "1209600",
+	}
+	m.zoneRecords[domainName] = nil
+	for _, record := range records {
+		m.addRecord(domainName, record)
+	}
+}
+
+// records returns a copy of the records of a zone.
+func (m *memoryDNS) records(domainName string) []client.DnsRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]client.DnsRecord(nil), m.zoneRecords[domainName]...)
+}
+
+func (m *memoryDNS) addRecord(domainName string, record client.DnsRecord) client.DnsRecord {
+	record.Id = strconv.Itoa(m.nextId)
+	record.State = client.RecordStateActive
+	record.DeleteRecord = false
+	m.nextId++
+	m.zoneRecords[domainName] = append(m.zoneRecords[domainName], record)
+	return record
+}
+
+func (m *memoryDNS) zone(domainName string) (*client.DnsZone, error) {
+	zone, ok := m.zones[domainName]
+	if !ok {
+		return nil, &client.APIError{Action: "infoDnsZone", StatusCode: 5029, ShortMessage: "Can not get DNS records for zone. Domain not found."}
+	}
+	return zone, nil
+}
+
+func (m *memoryDNS) GetDnsZone(ctx context.Context, domainName string) (*client.DnsZone, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	zone, err := m.zone(domainName)
+	if err != nil {
+		return nil, &client.DnsZoneNotFoundError{DomainName: domainName, Err: err}
+	}
+	z := *zone
+	return &z, nil
+}
+
+func (m *memoryDNS) UpdateDnsZone(ctx context.Context, domainName string, zone client.DnsZone) (*client.DnsZone, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, err := m.zone(domainName)
+	if err != nil {
+		return nil, err
+	}
+	zone.Name = domainName
+	zone.Serial = existing.Serial
+	*existing = zone
+	return &zone, nil
+}
+
+func (m *memoryDNS) GetDnsRecords(ctx context.Context, domainName string) ([]client.DnsRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.zone(domainName); err != nil {
+		return nil, err
+	}
+	return append([]client.DnsRecord(nil), m.zoneRecords[domainName]...), nil
+}
+
+func (m *memoryDNS) GetDnsRecordById(ctx context.Context, domainName string, id string) (*client.DnsRecord, error) {
+	records, err := m.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.Id == id {
+			return &record, nil
+		}
+	}
+	return nil, &client.DnsRecordNotFoundError{DomainName: domainName, Id: id}
+}
+
+func (m *memoryDNS) CreateDnsRecord(ctx context.Context, domainName string, record client.NewDnsRecord) (*client.DnsRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.zone(domainName); err != nil {
+		return nil, err
+	}
+	created := m.addRecord(domainName, client.DnsRecord{
+		Hostname:    record.Hostname,
+		Type:        record.Type,
+		Priority:    record.Priority,
+		Destination: record.Destination,
+	})
+	return &created, nil
+}
+
+func (m *memoryDNS) UpdateDnsRecord(ctx context.Context, domainName string, record client.DnsRecord) (*client.DnsRecord, error) {
+	records, err := m.UpdateDnsRecords(ctx, domainName, []client.DnsRecord{record})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if r.Id == record.Id {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find DNS record with ID %s", record.Id)
+}
+
+func (m *memoryDNS) DeleteDnsRecord(ctx context.Context, domainName string, record client.DnsRecord) error {
+	record.DeleteRecord = true
+	_, err := m.UpdateDnsRecords(ctx, domainName, []client.DnsRecord{record})
+	return err
+}
+
+func (m *memoryDNS) UpdateDnsRecords(ctx context.Context, domainName string, records []client.DnsRecord) ([]client.DnsRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.zone(domainName); err != nil {
+		return nil, err
+	}
+	// like the API, apply either all changes or none
+	for _, record := range records {
+		if record.Id != "" && indexOfRecord(m.zoneRecords[domainName], record.Id) < 0 {
+			return nil, &client.APIError{Action: "updateDnsRecords", StatusCode: 5028, ShortMessage: "DNS record not found: " + record.Id}
+		}
+	}
+	for _, record := range records {
+		existing := m.zoneRecords[domainName]
+		switch i := indexOfRecord(existing, record.Id); {
+		case record.Id == "":
+			m.addRecord(domainName, record)
+		case record.DeleteRecord:
+			m.zoneRecords[domainName] = append(existing[:i:i], existing[i+1:]...)
+		default:
+			record.State = client.RecordStateActive
+			existing[i] = record
+		}
+	}
+	return append([]client.DnsRecord(nil), m.zoneRecords[domainName]...), nil
+}
+
+func indexOfRecord(records []client.DnsRecord, id string) int {
+	for i, record := range records {
+		if record.Id == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -2,7 +2,17 @@ package provider
 
 import (
 	"context"
-	"github.com/rincedd/terraform-provider-netcup-ccp/internal/client"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/credentials"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -30,30 +40,103 @@ func New(version string) func() *schema.Provider {
 			Schema: map[string]*schema.Schema{
 				"customer_number": {
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CUSTOMER_NUMBER", nil),
 					Description: "Netcup customer number.",
 				},
 				"ccp_api_key": {
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CCP_API_KEY", nil),
 					Description: "Netcup CCP API key.",
 				},
 				"ccp_api_password": {
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
 					Sensitive:   true,
 					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CCP_API_PASSWORD", nil),
 					Description: "Netcup CCP API password.",
 				},
+				"ccp_api_password_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CCP_API_PASSWORD_FILE", nil),
+					Description: "Path to a file containing the Netcup CCP API password.",
+				},
+				"credentials_command": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Command that prints the credentials as a JSON object with the keys `customer_number`, `api_key` and `api_password`, given as program and arguments.",
+				},
+				"credentials_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CREDENTIALS_FILE", nil),
+					Description: "Path to a shared credentials file. Defaults to `~/.netcup/credentials`.",
+				},
+				"profile": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("NETCUP_PROFILE", credentials.DefaultProfile),
+					Description: "Profile to read from the shared credentials file.",
+				},
+				"endpoint": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("NETCUP_CCP_ENDPOINT", client.HostURL),
+					Description: "URL of the Netcup CCP API endpoint.",
+				},
+				"request_timeout": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     int(client.DefaultTimeout / time.Second),
+					Description: "Timeout of a single request to the CCP API in seconds.",
+				},
+				"proxy_url": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "URL of an HTTP proxy for requests to the CCP API. Defaults to the proxy configured in the `HTTPS_PROXY` environment variable.",
+				},
+				"ca_cert_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path to a PEM file with additional CA certificates to trust for the CCP API endpoint.",
+				},
+				"allowed_domains": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Domains this provider is allowed to change, including their subdomains. Changes to other domains fail before any request is sent. Defaults to all domains.",
+				},
+				"forbidden_domains": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Domains this provider must never change, including their subdomains. Takes precedence over `allowed_domains`.",
+				},
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Skip verification of the endpoint's TLS certificate. Only use this for testing.",
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"netcup-ccp_dns_zone":    dataSourceDnsZone(),
-				"netcup-ccp_dns_records": dataSourceDnsRecords(),
+				"netcup-ccp_account":          dataSourceAccount(),
+				"netcup-ccp_dns_zone":         dataSourceDnsZone(),
+				"netcup-ccp_dns_records":      dataSourceDnsRecords(),
+				"netcup-ccp_domain_auth_code": dataSourceDomainAuthCode(),
+				"netcup-ccp_tld_price":        dataSourceTldPrice(),
+				"netcup-ccp_zone_file":        dataSourceZoneFile(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"netcup-ccp_dns_record": resourceDnsRecord(),
+				"netcup-ccp_acme_challenge":  resourceAcmeChallenge(),
+				"netcup-ccp_dns_record":      resourceDnsRecord(),
+				"netcup-ccp_dns_zone":        resourceDnsZone(),
+				"netcup-ccp_domain":          resourceDomain(),
+				"netcup-ccp_domain_transfer": resourceDomainTransfer(),
+				"netcup-ccp_zone_import":     resourceZoneImport(),
 			},
 		}
 
@@ -65,14 +148,43 @@ func New(version string) func() *schema.Provider {
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		customerNumber := d.Get("customer_number").(string)
-		ccpApiKey := d.Get("ccp_api_key").(string)
-		ccpApiPassword := d.Get("ccp_api_password").(string)
-
 		var diags diag.Diagnostics
 
+		creds, err := resolveCredentials(ctx, d)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read credentials",
+				Detail:   err.Error(),
+			})
+			return nil, diags
+		}
+		customerNumber := creds.CustomerNumber
+		ccpApiKey := creds.APIKey
+		ccpApiPassword := creds.APIPassword
+
 		if (customerNumber != "") && (ccpApiKey != "") && (ccpApiPassword != "") {
-			ccpClient, err := client.NewCCPClient(customerNumber, ccpApiKey, ccpApiPassword)
+			httpClient, err := newHTTPClient(d)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid HTTP transport configuration",
+					Detail:   err.Error(),
+				})
+				return nil, diags
+			}
+
+			ccpClient, err := client.NewCCPClient(customerNumber, ccpApiKey, ccpApiPassword,
+				client.WithHTTPClient(httpClient),
+				client.WithEndpoint(d.Get("endpoint").(string)),
+				client.WithTimeout(time.Duration(d.Get("request_timeout").(int))*time.Second),
+				client.WithAllowedDomains(stringSet(d.Get("allowed_domains"))...),
+				client.WithForbiddenDomains(stringSet(d.Get("forbidden_domains"))...),
+				// log to the output of the standard logger, which Terraform filters by the level prefixes and TF_LOG
+				client.WithLogger(log.New(log.Writer(), "", log.Flags())),
+				// aliased providers for accounts that are not used in a run must not log in
+				client.WithLazyLogin(),
+			)
 			if err != nil {
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Error,
@@ -85,13 +197,93 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			userAgent := p.UserAgent("terraform-provider-netcup-ccp", version)
 			ccpClient.UserAgent = userAgent
 
-			return ccpClient, nil
+			return &providerMeta{CCPClient: ccpClient, zoneTTLs: newZoneTTLPlan()}, nil
 		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Missing customer number/API key/API password.",
-			Detail:   "Netcup customer number, API key, and API password are required.",
+			Detail: "Netcup customer number, API key, and API password are required. Set them in the provider configuration, " +
+				"the NETCUP_* environment variables, a password file, a credentials command or a shared credentials file.",
 		})
 		return nil, diags
 	}
 }
+
+// providerMeta is the meta of a configured provider, the client of its account and the state that the resources of the
+// configuration share while a plan is computed.
+type providerMeta struct {
+	*client.CCPClient
+	zoneTTLs *zoneTTLPlan
+}
+
+func (m *providerMeta) zoneTTLPlan() *zoneTTLPlan {
+	return m.zoneTTLs
+}
+
+// resolveCredentials returns the credentials of the first source configured for the provider that provides any, see
+// credentials.Resolve for their precedence. The attributes take precedence over all other sources.
+func resolveCredentials(ctx context.Context, d *schema.ResourceData) (credentials.Credentials, error) {
+	command := d.Get("credentials_command").([]interface{})
+	args := make([]string, len(command))
+	for i, arg := range command {
+		args[i], _ = arg.(string)
+	}
+
+	return credentials.Resolve(ctx, credentials.Sources{
+		Explicit: credentials.Credentials{
+			CustomerNumber: d.Get("customer_number").(string),
+			APIKey:         d.Get("ccp_api_key").(string),
+			APIPassword:    d.Get("ccp_api_password").(string),
+		},
+		PasswordFile: d.Get("ccp_api_password_file").(string),
+		Command:      args,
+		File:         d.Get("credentials_file").(string),
+		Profile:      d.Get("profile").(string),
+	})
+}
+
+func stringSet(v interface{}) []string {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, set.Len())
+	for _, value := range set.List() {
+		values = append(values, value.(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+func newHTTPClient(d *schema.ResourceData) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxyURL := d.Get("proxy_url").(string); proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url %q: %w", proxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_cert_file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in ca_cert_file %s", caCertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -1,20 +1,43 @@
 package provider
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/credentials"
+)
+
+const (
+	testAccCustomerNumber = "12345"
+	testAccApiKey         = "apikey"
+	testAccApiPassword    = "apipassword"
 )
 
 // providerFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
 var providerFactories = map[string]func() (*schema.Provider, error){
-	"scaffolding": func() (*schema.Provider, error) {
+	"netcup-ccp": func() (*schema.Provider, error) {
 		return New("dev")(), nil
 	},
 }
 
+var (
+	// testAccFake is the fake CCP API all acceptance tests run against, it is started by testAccPreCheck.
+	testAccFake     *ccpfake.Server
+	testAccFakeOnce sync.Once
+)
+
 func TestProvider(t *testing.T) {
 	if err := New("dev")().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -22,7 +45,173 @@ func TestProvider(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// The provider reads its configuration from the environment, so test configurations need no provider block
+	// and run against a fake CCP API on a local port that lives as long as the test binary.
+	testAccFakeOnce.Do(func() {
+		testAccFake = ccpfake.New(testAccCustomerNumber, testAccApiKey, testAccApiPassword)
+		srv := httptest.NewServer(testAccFake)
+
+		os.Setenv("NETCUP_CUSTOMER_NUMBER", testAccCustomerNumber)
+		os.Setenv("NETCUP_CCP_API_KEY", testAccApiKey)
+		os.Setenv("NETCUP_CCP_API_PASSWORD", testAccApiPassword)
+		os.Setenv("NETCUP_CCP_ENDPOINT", srv.URL)
+	})
+}
+
+// newTestClient returns a client of a fresh fake CCP API for unit tests that do not run Terraform.
+func newTestClient(t *testing.T) (*client.CCPClient, *ccpfake.Server) {
+	fake := ccpfake.New(testAccCustomerNumber, testAccApiKey, testAccApiPassword)
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	ccpClient, err := client.NewCCPClient(testAccCustomerNumber, testAccApiKey, testAccApiPassword, client.WithEndpoint(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ccpClient, fake
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	providerSchema := New("dev")().Schema
+
+	d := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"proxy_url":            "http://proxy.example.com:3128",
+		"insecure_skip_verify": true,
+	})
+	httpClient, err := newHTTPClient(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	transport := httpClient.Transport.(*http.Transport)
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("expected TLS verification to be disabled")
+	}
+	proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "ccp.netcup.net"}})
+	if err != nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("expected proxy to be used, got %v (%v)", proxy, err)
+	}
+
+	d = schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"ca_cert_file": "does-not-exist.pem",
+	})
+	if _, err := newHTTPClient(d); err == nil {
+		t.Errorf("expected error for missing CA certificate file")
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	defer unsetEnv("NETCUP_CUSTOMER_NUMBER", "NETCUP_CCP_API_KEY", "NETCUP_CCP_API_PASSWORD", "NETCUP_CCP_API_PASSWORD_FILE",
+		"NETCUP_CREDENTIALS_FILE", "NETCUP_PROFILE", "HOME")()
+	providerSchema := New("dev")().Schema
+
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// the default credentials file in the empty home directory does not exist
+	os.Setenv("HOME", dir)
+	credentialsFile := filepath.Join(dir, "credentials")
+	passwordFile := filepath.Join(dir, "password")
+	if err := ioutil.WriteFile(credentialsFile, []byte("[agency]\ncustomer_number = 67890\napi_key = FILE_KEY\napi_password = FILE_PASSWORD\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(passwordFile, []byte("PASSWORD_FROM_FILE\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected credentials.Credentials
+	}{
+		{
+			name: "attributes take precedence over all other sources",
+			raw: map[string]interface{}{
+				"customer_number":       "12345",
+				"ccp_api_key":           "KEY",
+				"ccp_api_password":      "PASSWORD",
+				"ccp_api_password_file": passwordFile,
+				"credentials_file":      credentialsFile,
+				"profile":               "agency",
+			},
+			expected: credentials.Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "PASSWORD"},
+		},
+		{
+			name: "password file completes the attributes",
+			raw: map[string]interface{}{
+				"customer_number":       "12345",
+				"ccp_api_key":           "KEY",
+				"ccp_api_password_file": passwordFile,
+				"credentials_command":   []interface{}{"echo", `{"customer_number": "13579", "api_key": "COMMAND_KEY", "api_password": "COMMAND_PASSWORD"}`},
+				"credentials_file":      credentialsFile,
+				"profile":               "agency",
+			},
+			expected: credentials.Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "PASSWORD_FROM_FILE"},
+		},
+		{
+			name: "credentials command takes precedence over the credentials file",
+			raw: map[string]interface{}{
+				"credentials_command": []interface{}{"echo", `{"customer_number": "13579", "api_key": "COMMAND_KEY", "api_password": "COMMAND_PASSWORD"}`},
+				"credentials_file":    credentialsFile,
+				"profile":             "agency",
+			},
+			expected: credentials.Credentials{CustomerNumber: "13579", APIKey: "COMMAND_KEY", APIPassword: "COMMAND_PASSWORD"},
+		},
+		{
+			name:     "missing default credentials file is ignored",
+			raw:      map[string]interface{}{},
+			expected: credentials.Credentials{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, providerSchema, c.raw)
+			creds, err := resolveCredentials(context.Background(), d)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if creds != c.expected {
+				t.Errorf("expected %+v, got %+v", c.expected, creds)
+			}
+		})
+	}
+
+	d := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"credentials_file": filepath.Join(dir, "does-not-exist"),
+	})
+	if _, err := resolveCredentials(context.Background(), d); err == nil {
+		t.Errorf("expected error for missing explicit credentials file")
+	}
+
+	// the password of the credentials file is not combined with the customer number of the attributes
+	d = schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"customer_number":  "12345",
+		"credentials_file": credentialsFile,
+		"profile":          "agency",
+	})
+	if _, err := resolveCredentials(context.Background(), d); err == nil {
+		t.Errorf("expected error for incomplete attributes")
+	}
+}
+
+// unsetEnv unsets environment variables and returns a function restoring them.
+func unsetEnv(keys ...string) func() {
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = value
+		}
+		os.Unsetenv(key)
+	}
+	return func() {
+		for _, key := range keys {
+			if value, ok := values[key]; ok {
+				os.Setenv(key, value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}
 }
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func resourceAcmeChallenge() *schema.Resource {
+	return &schema.Resource{
+		Description: "TXT records for an ACME DNS-01 challenge. The zone is determined from the domains of the account, " +
+			"the records are created and awaited before the resource is available and deleted on destroy.",
+
+		CreateContext: resourceAcmeChallengeCreate,
+		ReadContext:   resourceAcmeChallengeRead,
+		DeleteContext: resourceAcmeChallengeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fqdn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return canonicalFQDN(old) == canonicalFQDN(new)
+				},
+				Description: "Fully qualified name of the challenge record, e.g. `_acme-challenge.www.example.com`.",
+			},
+			"tokens": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Key authorization digests to publish, one TXT record each, e.g. for a certificate covering both a domain and its wildcard.",
+			},
+			"query_nameservers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Additionally wait until netcup's nameservers answer with all records.",
+			},
+			"domain_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Domain name of the zone the records were created in.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname of the records relative to the zone.",
+			},
+			"record_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the TXT records.",
+			},
+		},
+	}
+}
+
+func resourceAcmeChallengeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService, _ := m.(client.DNSService)
+	domainService, _ := m.(client.DomainService)
+	if dnsService == nil || domainService == nil {
+		return missingAcmeChallengeClient()
+	}
+	fqdn := canonicalFQDN(d.Get("fqdn").(string))
+
+	domainName, hostname, err := client.FindZone(ctx, domainService, fqdn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	existing, err := dnsService.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var records []client.DnsRecord
+	for _, token := range d.Get("tokens").(*schema.Set).List() {
+		desired := client.NewDnsRecord{Hostname: hostname, Type: "TXT", Destination: token.(string)}
+
+		// records left over from an earlier partially deleted challenge are reused instead of duplicated
+		if record := findAcmeChallengeRecord(existing, desired); record != nil {
+			records = append(records, *record)
+			continue
+		}
+
+		record, err := dnsService.CreateDnsRecord(ctx, domainName, desired)
+		if err != nil {
+			deleteAcmeChallengeRecords(ctx, dnsService, domainName, records)
+			return diag.FromErr(err)
+		}
+		records = append(records, *record)
+	}
+
+	d.SetId(fqdn)
+	d.Set("domain_name", domainName)
+	d.Set("name", hostname)
+	d.Set("record_ids", acmeChallengeRecordIds(records))
+
+	for _, record := range records {
+		if _, err := client.PollDnsRecordActive(ctx, dnsService, client.PollIntervalOf(m), domainName, record.Id); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.Get("query_nameservers").(bool) {
+		for _, record := range records {
+			if err := client.WaitForDnsRecordResolvable(ctx, client.NetcupNameservers, 5*time.Second, domainName, record); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceAcmeChallengeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService, _ := m.(client.DNSService)
+	if dnsService == nil {
+		return missingAcmeChallengeClient()
+	}
+
+	existing, err := dnsService.GetDnsRecords(ctx, d.Get("domain_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := map[string]bool{}
+	for _, record := range existing {
+		ids[record.Id] = true
+	}
+	for _, id := range d.Get("record_ids").([]interface{}) {
+		if !ids[id.(string)] {
+			// a record was deleted outside of Terraform, the challenge is recreated with the remaining records
+			d.SetId("")
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func resourceAcmeChallengeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService, _ := m.(client.DNSService)
+	if dnsService == nil {
+		return missingAcmeChallengeClient()
+	}
+	domainName := d.Get("domain_name").(string)
+
+	existing, err := dnsService.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var records []client.DnsRecord
+	for _, id := range d.Get("record_ids").([]interface{}) {
+		for _, record := range existing {
+			if record.Id == id.(string) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	if err := deleteAcmeChallengeRecords(ctx, dnsService, domainName, records); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// deleteAcmeChallengeRecords deletes all records and returns the first error.
+func deleteAcmeChallengeRecords(ctx context.Context, dnsService client.DNSService, domainName string, records []client.DnsRecord) error {
+	var firstErr error
+	for _, record := range records {
+		if err := dnsService.DeleteDnsRecord(ctx, domainName, record); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func missingAcmeChallengeClient() diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Missing Netcup CCP client",
+		Detail:   "Unable to manage ACME challenge records without Netcup CCP client",
+	}}
+}
+
+func findAcmeChallengeRecord(records []client.DnsRecord, desired client.NewDnsRecord) *client.DnsRecord {
+	for _, record := range records {
+		if desired.Matches(record) {
+			return &record
+		}
+	}
+	return nil
+}
+
+func acmeChallengeRecordIds(records []client.DnsRecord) []string {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.Id
+	}
+	return ids
+}
+
+func canonicalFQDN(fqdn string) string {
+	return strings.ToLower(strings.TrimSuffix(fqdn, "."))
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+)
+
+func TestAccResourceAcmeChallenge(t *testing.T) {
+	domainName := "acme.example.com"
+	config := fmt.Sprintf(`
+resource "netcup-ccp_acme_challenge" "test" {
+  fqdn   = "_acme-challenge.www.%s."
+  tokens = ["token-1", "token-2"]
+}
+`, domainName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddDomain(ccpfake.Domain{Name: domainName})
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckZoneRecordCount(domainName, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_acme_challenge.test", "id", "_acme-challenge.www."+domainName),
+					resource.TestCheckResourceAttr("netcup-ccp_acme_challenge.test", "domain_name", domainName),
+					resource.TestCheckResourceAttr("netcup-ccp_acme_challenge.test", "name", "_acme-challenge.www"),
+					resource.TestCheckResourceAttr("netcup-ccp_acme_challenge.test", "record_ids.#", "2"),
+					testAccCheckZoneRecordCount(domainName, 2),
+				),
+			},
+			// a record deleted outside of Terraform is recreated, the remaining one is reused
+			{
+				PreConfig: func() {
+					if err := testAccFake.RemoveRecord(domainName, testAccFake.Records(domainName)[0].Id); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_acme_challenge.test", "record_ids.#", "2"),
+					testAccCheckZoneRecordCount(domainName, 2),
+				),
+			},
+		},
+	})
+}
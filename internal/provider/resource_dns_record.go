@@ -2,13 +2,30 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/rincedd/terraform-provider-netcup-ccp/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+// Settings of on_conflict, see resourceDnsRecordCreate.
+const (
+	onConflictError     = "error"
+	onConflictAdopt     = "adopt"
+	onConflictDuplicate = "duplicate"
 )
 
 func resourceDnsRecord() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		// This description is used by the documentation generator and the language server.
 		Description: "DNS record",
 
@@ -16,59 +33,190 @@ func resourceDnsRecord() *schema.Resource {
 		ReadContext:   resourceDnsRecordRead,
 		UpdateContext: resourceDnsRecordUpdate,
 		DeleteContext: resourceDnsRecordDelete,
+		CustomizeDiff: resourceDnsRecordCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDnsRecordImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"domain_name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Domain name of the zone the record belongs to.",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Hostname of the record relative to the zone, `@` for the zone apex.",
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Record type, e.g. `A`, `AAAA`, `CNAME`, `MX` or `TXT`.",
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dnsRecordValueKeys,
+				Description:  "Destination of the record. For `SRV`, `CAA`, `TLSA` and `SSHFP` records it can be set with a block with structured data instead.",
 			},
 			"priority": {
-				Type:     schema.TypeString,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "Priority of `MX` and `SRV` records.",
+			},
+			"ttl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[1-9][0-9]*$`), "must be a positive number of seconds"),
+				Description: "TTL of the record in seconds. netcup only supports a TTL for all records of a zone, so this must equal the TTL " +
+					"of the zone unless `update_zone_ttl` is set. Records of a zone with different TTLs, or a TTL other than the one of its " +
+					"`netcup-ccp_dns_zone`, overwrite each other's zone TTL on every apply and are reported with a warning.",
+			},
+			"update_zone_ttl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Change the TTL of the zone, and thereby of all its records, to `ttl` when creating or updating the record.",
+			},
+			"check_existing_records": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  "0",
+				Default:  true,
+				Description: "Fail the plan if the record conflicts with an existing record of the zone, e.g. a CNAME record with other records " +
+					"of its hostname. Records deleted in the same apply still count as existing, set this to `false` to replace them.",
+			},
+			"on_conflict": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onConflictError,
+				ValidateFunc: validation.StringInSlice([]string{onConflictError, onConflictAdopt, onConflictDuplicate}, false),
+				Description: "What to do on create if the zone already contains an identical record: `error` fails with a hint how to import it, " +
+					"`adopt` manages the existing record, which is deleted on destroy, and `duplicate` creates another identical record.",
+			},
+			"wait_for_propagation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Wait until the record is active after creating or updating it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query_nameservers": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Additionally wait until the nameservers answer with the record. Only supported for A, AAAA, CNAME, MX, NS, SRV and TXT records.",
+						},
+						"nameservers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Nameservers to query as `host:port`. Defaults to netcup's authoritative nameservers.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
 			},
 		},
 	}
+	for key, blockSchema := range dnsRecordDataSchemas() {
+		r.Schema[key] = blockSchema
+	}
+	return r
 }
 
 func resourceDnsRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	destination, err := dnsRecordDestination(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	record := client.NewDnsRecord{
 		Hostname:    d.Get("name").(string),
 		Type:        d.Get("type").(string),
-		Destination: d.Get("value").(string),
+		Destination: destination,
 		Priority:    d.Get("priority").(string),
 	}
 	domainName := d.Get("domain_name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService := m.(client.DNSService)
 
-	newRecord, err := ccpClient.CreateDnsRecord(domainName, record)
+	diags := applyDnsRecordTTL(ctx, d, dnsService)
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, zoneTTLConflictWarning(m, domainName, d.Get("ttl").(string), dnsRecordTTLRequester(d))...)
+
+	if onConflict := d.Get("on_conflict").(string); onConflict != onConflictDuplicate {
+		existing, err := findExistingDnsRecord(ctx, dnsService, domainName, record)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if existing != nil && onConflict == onConflictAdopt {
+			d.SetId(existing.Id)
+			d.Set("value", existing.Destination)
+			return append(diags, waitForDnsRecordPropagation(ctx, d, dnsService, *existing)...)
+		}
+		if existing != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "DNS record already exists",
+				Detail: fmt.Sprintf("Zone %s already contains the %s record %s with value %q and ID %s. "+
+					"Import it with `terraform import <resource address> %s/%s`, or set on_conflict to \"adopt\" to manage it "+
+					"or to \"duplicate\" to create another identical record.",
+					domainName, record.Type, record.Hostname, record.Destination, existing.Id, domainName, existing.Id),
+			})
+		}
+	}
+
+	newRecord, err := dnsService.CreateDnsRecord(ctx, domainName, record)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	d.SetId(newRecord.Id)
+	d.Set("value", newRecord.Destination)
 
-	return nil
+	return append(diags, waitForDnsRecordPropagation(ctx, d, dnsService, *newRecord)...)
+}
+
+// findExistingDnsRecord returns the first record of the zone that is identical to record, nil if there is none.
+func findExistingDnsRecord(ctx context.Context, dnsService client.DNSService, domainName string, record client.NewDnsRecord) (*client.DnsRecord, error) {
+	existing, err := dnsService.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	desired := client.DnsRecord{
+		Hostname:    record.Hostname,
+		Type:        record.Type,
+		Priority:    record.Priority,
+		Destination: record.Destination,
+	}
+	for i := range existing {
+		if existing[i].Matches(desired) {
+			return &existing[i], nil
+		}
+	}
+	return nil, nil
 }
 
 func resourceDnsRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	domainName := d.Get("domain_name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService := m.(client.DNSService)
 
-	record, err := ccpClient.GetDnsRecordById(domainName, d.Id())
+	record, err := dnsService.GetDnsRecordById(ctx, domainName, d.Id())
+	var notFound *client.DnsRecordNotFoundError
+	if errors.As(err, &notFound) {
+		// the record was deleted outside of Terraform
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -78,24 +226,75 @@ func resourceDnsRecordRead(ctx context.Context, d *schema.ResourceData, m interf
 	d.Set("value", record.Destination)
 	d.Set("priority", record.Priority)
 
-	return nil
+	var diags diag.Diagnostics
+	if block := dnsRecordDataBlock(d); block != "" {
+		data, err := flattenDnsRecordData(block, record.Destination)
+		if err != nil {
+			// keep the block, the diff on value shows the change
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to parse DNS record destination",
+				Detail:   fmt.Sprintf("Unable to parse the destination of record %s into the %s block: %s", d.Id(), block, err),
+			})
+		} else {
+			d.Set(block, data)
+		}
+	}
+
+	if d.Get("ttl").(string) == "" {
+		return diags
+	}
+	// the TTL of a record is the TTL of its zone, which may have been changed outside of Terraform or by other records
+	zone, err := dnsService.GetDnsZone(ctx, domainName)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.Set("ttl", zone.TTL)
+
+	return diags
+}
+
+// resourceDnsRecordImport imports a record by an ID of the form <domain name>/<record ID>.
+func resourceDnsRecordImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <domain name>/<record ID>", d.Id())
+	}
+
+	d.Set("domain_name", parts[0])
+	d.Set("on_conflict", onConflictError)
+	d.Set("update_zone_ttl", false)
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceDnsRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	domainName := d.Get("domain_name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService := m.(client.DNSService)
+
+	destination, err := dnsRecordDestination(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	record, err := ccpClient.UpdateDnsRecord(domainName, client.DnsRecord{
+	diags := applyDnsRecordTTL(ctx, d, dnsService)
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, zoneTTLConflictWarning(m, domainName, d.Get("ttl").(string), dnsRecordTTLRequester(d))...)
+
+	record, err := dnsService.UpdateDnsRecord(ctx, domainName, client.DnsRecord{
 		Id:           d.Id(),
 		Hostname:     d.Get("name").(string),
 		Type:         d.Get("type").(string),
 		Priority:     d.Get("priority").(string),
-		Destination:  d.Get("value").(string),
+		Destination:  destination,
 		DeleteRecord: false,
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	d.Set("name", record.Hostname)
@@ -103,14 +302,14 @@ func resourceDnsRecordUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("value", record.Destination)
 	d.Set("priority", record.Priority)
 
-	return nil
+	return append(diags, waitForDnsRecordPropagation(ctx, d, dnsService, *record)...)
 }
 
 func resourceDnsRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	domainName := d.Get("domain_name").(string)
-	ccpClient := m.(*client.CCPClient)
+	dnsService := m.(client.DNSService)
 
-	err := ccpClient.DeleteDnsRecord(domainName, client.DnsRecord{
+	err := dnsService.DeleteDnsRecord(ctx, domainName, client.DnsRecord{
 		Id:          d.Id(),
 		Hostname:    d.Get("name").(string),
 		Type:        d.Get("type").(string),
@@ -124,3 +323,267 @@ func resourceDnsRecordDelete(ctx context.Context, d *schema.ResourceData, m inte
 
 	return nil
 }
+
+// applyDnsRecordTTL changes the TTL of the zone to the ttl of the record if update_zone_ttl is set and fails if they
+// differ otherwise.
+func applyDnsRecordTTL(ctx context.Context, d *schema.ResourceData, dnsService client.DNSService) diag.Diagnostics {
+	ttl := d.Get("ttl").(string)
+	if ttl == "" {
+		return nil
+	}
+	domainName := d.Get("domain_name").(string)
+
+	zone, err := dnsService.GetDnsZone(ctx, domainName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if zone.TTL == ttl {
+		return nil
+	}
+	if !d.Get("update_zone_ttl").(bool) {
+		return diag.Diagnostics{dnsRecordTTLMismatch(domainName, ttl, zone.TTL)}
+	}
+
+	zone.TTL = ttl
+	if _, err := dnsService.UpdateDnsZone(ctx, domainName, *zone); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func dnsRecordTTLMismatch(domainName string, ttl string, zoneTTL string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "TTL of DNS record differs from the zone TTL",
+		Detail: fmt.Sprintf("The record has a TTL of %s seconds, but zone %s has a TTL of %s seconds. netcup only supports a TTL "+
+			"for all records of a zone, set update_zone_ttl to change the TTL of the zone.", ttl, domainName, zoneTTL),
+	}
+}
+
+// zoneTTLPlan collects the TTLs that the netcup-ccp_dns_record and netcup-ccp_dns_zone resources of a provider
+// configuration request for their zones while a plan is computed. netcup only supports a TTL for all records of a
+// zone, so resources of a zone that request different TTLs change the zone TTL back and forth on every apply. Such
+// conflicts are logged at plan time and reported as warnings when the resources are applied, see zoneTTLConflictWarning.
+type zoneTTLPlan struct {
+	mu sync.Mutex
+	// zones maps domain names to the requested TTLs and the first resource requesting each of them
+	zones map[string]map[string]string
+}
+
+// zoneTTLPlanner is implemented by the meta of the provider, see providerMeta.
+type zoneTTLPlanner interface {
+	zoneTTLPlan() *zoneTTLPlan
+}
+
+func newZoneTTLPlan() *zoneTTLPlan {
+	return &zoneTTLPlan{zones: map[string]map[string]string{}}
+}
+
+// request registers the ttl requested by requester for the zone of domainName and returns the conflict with the TTLs
+// requested by other resources, see conflict.
+func (p *zoneTTLPlan) request(domainName string, ttl string, requester string) error {
+	p.mu.Lock()
+	ttls, ok := p.zones[zoneTTLKey(domainName)]
+	if !ok {
+		ttls = map[string]string{}
+		p.zones[zoneTTLKey(domainName)] = ttls
+	}
+	if _, ok := ttls[ttl]; !ok {
+		ttls[ttl] = requester
+	}
+	p.mu.Unlock()
+
+	return p.conflict(domainName, ttl, requester)
+}
+
+// conflict returns an error listing the resources that requested a TTL other than ttl for the zone of domainName, nil
+// if there are none.
+func (p *zoneTTLPlan) conflict(domainName string, ttl string, requester string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var others []string
+	for other, otherRequester := range p.zones[zoneTTLKey(domainName)] {
+		if other != ttl {
+			others = append(others, fmt.Sprintf("%s requests %s seconds", otherRequester, other))
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	sort.Strings(others)
+	return fmt.Errorf("resources of zone %s request different TTLs: %s requests %s seconds, %s. netcup only supports a "+
+		"TTL for all records of a zone, configure the same TTL for all of them", zoneTTLKey(domainName), requester, ttl,
+		strings.Join(others, ", "))
+}
+
+func zoneTTLKey(domainName string) string {
+	return strings.ToLower(strings.TrimSuffix(domainName, "."))
+}
+
+// zoneTTLConflictWarning warns if another resource of the provider configuration requested a TTL other than ttl for
+// the zone of domainName, see zoneTTLPlan.
+func zoneTTLConflictWarning(m interface{}, domainName string, ttl string, requester string) diag.Diagnostics {
+	planner, ok := m.(zoneTTLPlanner)
+	if !ok || ttl == "" {
+		return nil
+	}
+	if err := planner.zoneTTLPlan().conflict(domainName, ttl, requester); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Conflicting TTLs for DNS zone",
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
+
+// dnsRecordTTLRequester describes a record in the conflicts of a zoneTTLPlan.
+func dnsRecordTTLRequester(d interface{ Get(string) interface{} }) string {
+	return fmt.Sprintf("%s record %s", d.Get("type").(string), d.Get("name").(string))
+}
+
+// resourceDnsRecordCustomizeDiff checks at plan time that the planned record does not conflict with the other records
+// of the zone unless check_existing_records is false, see client.CheckDnsRecords, and that its ttl matches the zone TTL unless update_zone_ttl is set. It
+// registers the ttl in the zoneTTLPlan of the provider configuration. Records planned
+// by other resources cannot be taken into account for conflicts. The checks against the zone are skipped if the zone
+// cannot be retrieved yet, e.g. because its domain is registered in the same run.
+func resourceDnsRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// plan the value rendered from a changed block with structured record data, unchanged blocks are read from value
+	block := dnsRecordDataBlock(d)
+	if block != "" && d.NewValueKnown(block) && d.NewValueKnown("type") && (d.Id() == "" || d.HasChange(block) || d.HasChange("type")) {
+		destination, err := dnsRecordDestination(d)
+		if err != nil {
+			return err
+		}
+		if destination != d.Get("value").(string) {
+			if err := d.SetNew("value", destination); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range []string{"domain_name", "name", "type", "value", "priority", "ttl", "update_zone_ttl"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	if planner, ok := m.(zoneTTLPlanner); ok && d.Get("ttl").(string) != "" {
+		// a plan cannot carry warnings, the conflict is reported again when the record is applied
+		if err := planner.zoneTTLPlan().request(d.Get("domain_name").(string), d.Get("ttl").(string), dnsRecordTTLRequester(d)); err != nil {
+			log.Printf("[WARN] %s", err)
+		}
+	}
+	dnsService, ok := m.(client.DNSService)
+	if !ok {
+		return nil
+	}
+
+	if err := checkDnsRecordTTL(ctx, d, dnsService); err != nil {
+		return err
+	}
+	if d.Id() != "" && !d.HasChange("name") && !d.HasChange("type") && !d.HasChange("value") {
+		return nil
+	}
+
+	domainName := d.Get("domain_name").(string)
+	planned := client.DnsRecord{
+		Id:          d.Id(),
+		Hostname:    d.Get("name").(string),
+		Type:        d.Get("type").(string),
+		Priority:    d.Get("priority").(string),
+		Destination: d.Get("value").(string),
+	}
+	if !d.Get("check_existing_records").(bool) {
+		return dnsRecordConflictsError(domainName, client.CheckDnsRecord(domainName, nil, planned))
+	}
+
+	existing, err := dnsService.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		log.Printf("[DEBUG] Skipping conflict check of DNS record, unable to retrieve records of %s: %s", domainName, err)
+		return nil
+	}
+
+	var others []client.DnsRecord
+	for _, record := range existing {
+		// an identical record is adopted or reported on create, unless it is meant to be duplicated
+		if record.Id == planned.Id || (planned.Id == "" && record.Matches(planned) && d.Get("on_conflict").(string) != onConflictDuplicate) {
+			continue
+		}
+		others = append(others, record)
+	}
+	return dnsRecordConflictsError(domainName, client.CheckDnsRecord(domainName, others, planned))
+}
+
+// checkDnsRecordTTL fails if a changed ttl differs from the zone TTL and update_zone_ttl is not set.
+func checkDnsRecordTTL(ctx context.Context, d *schema.ResourceDiff, dnsService client.DNSService) error {
+	ttl := d.Get("ttl").(string)
+	if ttl == "" || d.Get("update_zone_ttl").(bool) || (d.Id() != "" && !d.HasChange("ttl") && !d.HasChange("update_zone_ttl")) {
+		return nil
+	}
+	domainName := d.Get("domain_name").(string)
+
+	zone, err := dnsService.GetDnsZone(ctx, domainName)
+	if err != nil {
+		log.Printf("[DEBUG] Skipping TTL check of DNS record, unable to retrieve zone %s: %s", domainName, err)
+		return nil
+	}
+	if zone.TTL != ttl {
+		mismatch := dnsRecordTTLMismatch(domainName, ttl, zone.TTL)
+		return fmt.Errorf("%s: %s", mismatch.Summary, mismatch.Detail)
+	}
+	return nil
+}
+
+// dnsRecordConflictsError returns an error listing all conflicts, nil if there are none.
+func dnsRecordConflictsError(domainName string, conflicts []client.DnsRecordConflict) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	messages := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		messages[i] = "\n  - " + conflict.Error()
+	}
+	return fmt.Errorf("conflicting DNS records in zone %s:%s\nSet check_existing_records to false if the existing records "+
+		"are deleted in the same apply", domainName, strings.Join(messages, ""))
+}
+
+// waitForDnsRecordPropagation waits for the record to become active and resolvable as configured in the
+// wait_for_propagation block of the resource.
+func waitForDnsRecordPropagation(ctx context.Context, d *schema.ResourceData, dnsService client.DNSService, record client.DnsRecord) diag.Diagnostics {
+	settings := d.Get("wait_for_propagation").([]interface{})
+	if len(settings) == 0 {
+		return nil
+	}
+	domainName := d.Get("domain_name").(string)
+
+	if _, err := client.PollDnsRecordActive(ctx, dnsService, client.PollIntervalOf(dnsService), domainName, record.Id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	setting, ok := settings[0].(map[string]interface{})
+	if !ok || !setting["query_nameservers"].(bool) {
+		return nil
+	}
+
+	if !client.IsResolvableType(record.Type) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Cannot check propagation of DNS record",
+			Detail:   "Querying nameservers is not supported for " + record.Type + " records, only waited for the record to become active.",
+		}}
+	}
+
+	nameservers := client.NetcupNameservers
+	if configured := setting["nameservers"].([]interface{}); len(configured) > 0 {
+		nameservers = make([]string, len(configured))
+		for i, nameserver := range configured {
+			nameservers[i] = nameserver.(string)
+		}
+	}
+
+	if err := client.WaitForDnsRecordResolvable(ctx, nameservers, 5*time.Second, domainName, record); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+// dnsRecordDataTypes maps the blocks of netcup-ccp_dns_record with structured record data to their record types.
+var dnsRecordDataTypes = map[string]string{
+	"srv":   "SRV",
+	"caa":   "CAA",
+	"tlsa":  "TLSA",
+	"sshfp": "SSHFP",
+}
+
+// dnsRecordValueKeys are the attributes of netcup-ccp_dns_record of which exactly one sets the destination.
+var dnsRecordValueKeys = []string{"value", "srv", "caa", "tlsa", "sshfp"}
+
+// resourceDataGetter is implemented by schema.ResourceData and schema.ResourceDiff.
+type resourceDataGetter interface {
+	Get(key string) interface{}
+}
+
+// dnsRecordDataSchemas returns the blocks of netcup-ccp_dns_record with structured record data.
+func dnsRecordDataSchemas() map[string]*schema.Schema {
+	uint8Field := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 255),
+			Description:  description,
+		}
+	}
+	uint16Field := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntBetween(0, 65535),
+			Description:  description,
+		}
+	}
+	stringField := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: description,
+		}
+	}
+	block := func(description string, fields map[string]*schema.Schema) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: dnsRecordValueKeys,
+			Description:  description,
+			Elem:         &schema.Resource{Schema: fields},
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"srv": block("Destination of an `SRV` record, instead of `value`. The priority is set with `priority`.", map[string]*schema.Schema{
+			"weight": uint16Field("Weight of the target among targets with the same priority."),
+			"port":   uint16Field("Port of the service."),
+			"target": stringField("Hostname of the target."),
+		}),
+		"caa": block("Destination of a `CAA` record, instead of `value`.", map[string]*schema.Schema{
+			"flags": uint8Field("Flags of the property, `128` marks it as critical."),
+			"tag":   stringField("Tag of the property, e.g. `issue`, `issuewild` or `iodef`."),
+			"value": stringField("Value of the property, without quotes."),
+		}),
+		"tlsa": block("Destination of a `TLSA` record, instead of `value`.", map[string]*schema.Schema{
+			"usage":         uint8Field("Certificate usage."),
+			"selector":      uint8Field("Part of the certificate that is matched."),
+			"matching_type": uint8Field("How the certificate association data is matched."),
+			"data":          stringField("Certificate association data in hex."),
+		}),
+		"sshfp": block("Destination of an `SSHFP` record, instead of `value`.", map[string]*schema.Schema{
+			"algorithm":   uint8Field("Algorithm of the SSH key."),
+			"type":        uint8Field("Type of the fingerprint."),
+			"fingerprint": stringField("Fingerprint of the SSH key in hex."),
+		}),
+	}
+}
+
+// dnsRecordDataBlock returns the name of the configured block with structured record data, "" if there is none.
+func dnsRecordDataBlock(d resourceDataGetter) string {
+	for block := range dnsRecordDataTypes {
+		if len(d.Get(block).([]interface{})) > 0 {
+			return block
+		}
+	}
+	return ""
+}
+
+// dnsRecordDestination returns the destination of a record, rendered from its block with structured record data or
+// taken from value.
+func dnsRecordDestination(d resourceDataGetter) (string, error) {
+	block := dnsRecordDataBlock(d)
+	if block == "" {
+		return d.Get("value").(string), nil
+	}
+	if recordType := d.Get("type").(string); !strings.EqualFold(recordType, dnsRecordDataTypes[block]) {
+		return "", fmt.Errorf("the %s block can only be used for %s records, not for %s records", block, dnsRecordDataTypes[block], recordType)
+	}
+
+	data, _ := d.Get(block).([]interface{})[0].(map[string]interface{})
+	switch block {
+	case "srv":
+		return client.SRVData{Weight: data["weight"].(int), Port: data["port"].(int), Target: data["target"].(string)}.Destination(), nil
+	case "caa":
+		return client.CAAData{Flags: data["flags"].(int), Tag: data["tag"].(string), Value: data["value"].(string)}.Destination(), nil
+	case "tlsa":
+		return client.TLSAData{
+			Usage:        data["usage"].(int),
+			Selector:     data["selector"].(int),
+			MatchingType: data["matching_type"].(int),
+			Data:         data["data"].(string),
+		}.Destination(), nil
+	default:
+		return client.SSHFPData{Algorithm: data["algorithm"].(int), Type: data["type"].(int), Fingerprint: data["fingerprint"].(string)}.Destination(), nil
+	}
+}
+
+// flattenDnsRecordData parses the destination of a record into the content of the given block with structured
+// record data.
+func flattenDnsRecordData(block string, destination string) ([]interface{}, error) {
+	var data map[string]interface{}
+	switch block {
+	case "srv":
+		srv, err := client.ParseSRVData(destination)
+		if err != nil {
+			return nil, err
+		}
+		data = map[string]interface{}{"weight": srv.Weight, "port": srv.Port, "target": srv.Target}
+	case "caa":
+		caa, err := client.ParseCAAData(destination)
+		if err != nil {
+			return nil, err
+		}
+		data = map[string]interface{}{"flags": caa.Flags, "tag": caa.Tag, "value": caa.Value}
+	case "tlsa":
+		tlsa, err := client.ParseTLSAData(destination)
+		if err != nil {
+			return nil, err
+		}
+		data = map[string]interface{}{"usage": tlsa.Usage, "selector": tlsa.Selector, "matching_type": tlsa.MatchingType, "data": tlsa.Data}
+	case "sshfp":
+		sshfp, err := client.ParseSSHFPData(destination)
+		if err != nil {
+			return nil, err
+		}
+		data = map[string]interface{}{"algorithm": sshfp.Algorithm, "type": sshfp.Type, "fingerprint": sshfp.Fingerprint}
+	default:
+		return nil, fmt.Errorf("unknown record data block %s", block)
+	}
+	return []interface{}{data}, nil
+}
@@ -0,0 +1,487 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestAccResourceDnsRecord(t *testing.T) {
+	domainName := "record.example.com"
+	var recordId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckDnsRecordsDestroyed(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDnsRecord(domainName, "1.2.3.4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_dns_record.test", "name", "www"),
+					resource.TestCheckResourceAttr("netcup-ccp_dns_record.test", "type", "A"),
+					resource.TestCheckResourceAttr("netcup-ccp_dns_record.test", "value", "1.2.3.4"),
+					testAccCheckDnsRecordExists("netcup-ccp_dns_record.test", &recordId),
+				),
+			},
+			// update in place
+			{
+				Config: testAccResourceDnsRecord(domainName, "5.6.7.8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_dns_record.test", "value", "5.6.7.8"),
+					resource.TestCheckResourceAttrPtr("netcup-ccp_dns_record.test", "id", &recordId),
+					testAccCheckDnsRecordExists("netcup-ccp_dns_record.test", &recordId),
+				),
+			},
+			// import
+			{
+				ResourceName:      "netcup-ccp_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return domainName + "/" + recordId, nil
+				},
+			},
+			// drift: the record is deleted outside of Terraform and recreated
+			{
+				PreConfig: func() {
+					if err := testAccFake.RemoveRecord(domainName, recordId); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccResourceDnsRecord(domainName, "5.6.7.8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_dns_record.test", "value", "5.6.7.8"),
+					testAccCheckDnsRecordExists("netcup-ccp_dns_record.test", &recordId),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceDnsRecord_waitForPropagation(t *testing.T) {
+	domainName := "propagation.example.com"
+	var recordId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckDnsRecordsDestroyed(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netcup-ccp_dns_record" "test" {
+  domain_name = %q
+  name        = "_acme-challenge"
+  type        = "TXT"
+  value       = "token"
+
+  wait_for_propagation {}
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDnsRecordExists("netcup-ccp_dns_record.test", &recordId),
+					func(*terraform.State) error {
+						for _, record := range testAccFake.Records(domainName) {
+							if record.Id == recordId && record.State != "yes" {
+								return fmt.Errorf("record %s is not active yet", recordId)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceDnsRecord_forbiddenDomain(t *testing.T) {
+	domainName := "forbidden.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckDnsRecordsDestroyed(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "netcup-ccp" {
+  forbidden_domains = [%q]
+}
+`, domainName) + testAccResourceDnsRecord(domainName, "1.2.3.4"),
+				ExpectError: regexp.MustCompile("changes to domain forbidden.example.com are not allowed"),
+			},
+		},
+	})
+}
+
+// testAccCheckDnsRecordExists verifies that the fake API holds the record of the resource and stores its ID in id.
+func testAccCheckDnsRecordExists(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		domainName := rs.Primary.Attributes["domain_name"]
+
+		for _, record := range testAccFake.Records(domainName) {
+			if record.Id == rs.Primary.ID {
+				if record.Destination != rs.Primary.Attributes["value"] {
+					return fmt.Errorf("record %s has value %q, expected %q", record.Id, record.Destination, rs.Primary.Attributes["value"])
+				}
+				*id = record.Id
+				return nil
+			}
+		}
+		return fmt.Errorf("record %s does not exist in zone %s", rs.Primary.ID, domainName)
+	}
+}
+
+func testAccCheckDnsRecordsDestroyed(domainName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if records := testAccFake.Records(domainName); len(records) > 0 {
+			return fmt.Errorf("zone %s still contains %d records", domainName, len(records))
+		}
+		return nil
+	}
+}
+
+func testAccResourceDnsRecord(domainName string, value string) string {
+	return fmt.Sprintf(`
+resource "netcup-ccp_dns_record" "test" {
+  domain_name = %q
+  name        = "www"
+  type        = "A"
+  value       = %q
+}
+`, domainName, value)
+}
+
+func TestResourceDnsRecordCRUD(t *testing.T) {
+	ctx := context.Background()
+	dns := newMemoryDNS()
+	dns.addZone("example.com", client.DnsRecord{Hostname: "mail", Type: "A", Destination: "1.2.3.4"})
+
+	d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, map[string]interface{}{
+		"domain_name": "example.com",
+		"name":        "www",
+		"type":        "A",
+		"value":       "5.6.7.8",
+	})
+
+	if diags := resourceDnsRecordCreate(ctx, d, dns); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	record, err := dns.GetDnsRecordById(ctx, "example.com", d.Id())
+	if err != nil {
+		t.Fatalf("created record: %v", err)
+	}
+	if record.Hostname != "www" || record.Destination != "5.6.7.8" {
+		t.Errorf("created record %+v, expected www A 5.6.7.8", record)
+	}
+
+	d.Set("value", "9.9.9.9")
+	if diags := resourceDnsRecordUpdate(ctx, d, dns); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if record, _ := dns.GetDnsRecordById(ctx, "example.com", d.Id()); record.Destination != "9.9.9.9" {
+		t.Errorf("updated record has value %q, expected 9.9.9.9", record.Destination)
+	}
+
+	if diags := resourceDnsRecordDelete(ctx, d, dns); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if records := dns.records("example.com"); len(records) != 1 || records[0].Hostname != "mail" {
+		t.Errorf("zone contains %+v after delete, expected only the mail record", records)
+	}
+
+	// the record is gone, so reading it removes it from the state
+	if diags := resourceDnsRecordRead(ctx, d, dns); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID is %q after reading a deleted record, expected it to be removed", d.Id())
+	}
+}
+
+func TestResourceDnsRecordRead(t *testing.T) {
+	dns := newMemoryDNS()
+	dns.addZone("example.com", client.DnsRecord{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"})
+
+	d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, map[string]interface{}{"domain_name": "example.com"})
+	d.SetId(dns.records("example.com")[0].Id)
+
+	if diags := resourceDnsRecordRead(context.Background(), d, dns); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	for key, expected := range map[string]string{"name": "@", "type": "MX", "priority": "10", "value": "mail.example.com"} {
+		if actual := d.Get(key).(string); actual != expected {
+			t.Errorf("%s is %q, expected %q", key, actual, expected)
+		}
+	}
+}
+
+func TestResourceDnsRecordCreate_onConflict(t *testing.T) {
+	for _, tc := range []struct {
+		onConflict string
+		records    int
+		adopted    bool
+		fails      bool
+	}{
+		{onConflict: "error", records: 1, fails: true},
+		{onConflict: "adopt", records: 1, adopted: true},
+		{onConflict: "duplicate", records: 2},
+	} {
+		t.Run(tc.onConflict, func(t *testing.T) {
+			dns := newMemoryDNS()
+			dns.addZone("example.com", client.DnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			existingId := dns.records("example.com")[0].Id
+
+			d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, map[string]interface{}{
+				"domain_name": "example.com",
+				"name":        "www",
+				"type":        "A",
+				"value":       "1.2.3.4",
+				"on_conflict": tc.onConflict,
+			})
+			diags := resourceDnsRecordCreate(context.Background(), d, dns)
+
+			if diags.HasError() != tc.fails {
+				t.Errorf("create returned %v, expected an error: %t", diags, tc.fails)
+			}
+			if tc.fails && !strings.Contains(diags[0].Detail, "terraform import <resource address> example.com/"+existingId) {
+				t.Errorf("error %q does not explain how to import the record", diags[0].Detail)
+			}
+			if records := dns.records("example.com"); len(records) != tc.records {
+				t.Errorf("zone contains %d records, expected %d", len(records), tc.records)
+			}
+			if adopted := d.Id() == existingId; adopted != tc.adopted {
+				t.Errorf("resource has ID %q, expected the existing record to be adopted: %t", d.Id(), tc.adopted)
+			}
+		})
+	}
+}
+
+func TestResourceDnsRecordCustomizeDiff(t *testing.T) {
+	dns := newMemoryDNS()
+	dns.addZone("example.com",
+		client.DnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"},
+		client.DnsRecord{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"},
+	)
+
+	for _, tc := range []struct {
+		name     string
+		hostname string
+		conflict string
+	}{
+		{name: "CNAME next to other records", hostname: "www", conflict: "a hostname with a CNAME record cannot have other records"},
+		{name: "CNAME at the apex", hostname: "@", conflict: "the zone apex cannot have a CNAME record"},
+		{name: "CNAME as MX target", hostname: "mail", conflict: "MX records must not point to a hostname with a CNAME record"},
+		{name: "CNAME without conflicts", hostname: "blog"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resourceDnsRecord().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"domain_name": "example.com",
+				"name":        tc.hostname,
+				"type":        "CNAME",
+				"value":       "target.example.org",
+			}), dns)
+
+			if tc.conflict == "" && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if tc.conflict != "" && (err == nil || !strings.Contains(err.Error(), tc.conflict)) {
+				t.Errorf("error %v does not report %q", err, tc.conflict)
+			}
+		})
+	}
+
+	t.Run("without checking existing records", func(t *testing.T) {
+		// e.g. the A record of www is destroyed in the same apply
+		_, err := resourceDnsRecord().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain_name":            "example.com",
+			"name":                   "www",
+			"type":                   "CNAME",
+			"value":                  "target.example.org",
+			"check_existing_records": false,
+		}), dns)
+
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestResourceDnsRecordCreate_ttl(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("differing from the zone TTL", func(t *testing.T) {
+		dns := newMemoryDNS()
+		dns.addZone("ttl-mismatch.example.com")
+
+		d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, map[string]interface{}{
+			"domain_name": "ttl-mismatch.example.com",
+			"name":        "www",
+			"type":        "A",
+			"value":       "1.2.3.4",
+			"ttl":         "300",
+		})
+		diags := resourceDnsRecordCreate(ctx, d, dns)
+
+		if !diags.HasError() || diags[0].Summary != "TTL of DNS record differs from the zone TTL" {
+			t.Errorf("expected the TTL to be rejected, got %v", diags)
+		}
+		if records := dns.records("ttl-mismatch.example.com"); len(records) > 0 {
+			t.Errorf("record was created despite the TTL mismatch: %+v", records)
+		}
+	})
+
+	t.Run("propagated to the zone", func(t *testing.T) {
+		dns := newMemoryDNS()
+		dns.addZone("ttl-update.example.com")
+
+		for i, ttl := range []string{"300", "600"} {
+			d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, map[string]interface{}{
+				"domain_name":     "ttl-update.example.com",
+				"name":            fmt.Sprintf("host%d", i),
+				"type":            "A",
+				"value":           "1.2.3.4",
+				"ttl":             ttl,
+				"update_zone_ttl": true,
+			})
+			if diags := resourceDnsRecordCreate(ctx, d, dns); diags.HasError() {
+				t.Fatalf("create: %v", diags)
+			}
+
+			zone, _ := dns.GetDnsZone(ctx, "ttl-update.example.com")
+			if zone.TTL != ttl {
+				t.Errorf("zone TTL is %s, expected %s", zone.TTL, ttl)
+			}
+		}
+	})
+}
+
+func TestResourceDnsRecordCustomizeDiff_ttl(t *testing.T) {
+	ctx := context.Background()
+	config := func(name string, ttl string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain_name":     "example.com",
+			"name":            name,
+			"type":            "A",
+			"value":           "1.2.3.4",
+			"ttl":             ttl,
+			"update_zone_ttl": true,
+		}
+	}
+	plan := func(dns *memoryDNS, name string, ttl string) {
+		if _, err := resourceDnsRecord().Diff(ctx, nil, terraform.NewResourceConfigRaw(config(name, ttl)), dns); err != nil {
+			t.Fatalf("records with different TTLs must not fail the plan: %v", err)
+		}
+	}
+	create := func(dns *memoryDNS, name string, ttl string) diag.Diagnostics {
+		diags := resourceDnsRecordCreate(ctx, schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, config(name, ttl)), dns)
+		if diags.HasError() {
+			t.Fatalf("create: %v", diags)
+		}
+		return diags
+	}
+
+	dns := newMemoryDNS()
+	dns.addZone("example.com")
+	plan(dns, "www", "300")
+	plan(dns, "mail", "300")
+	plan(dns, "ftp", "600")
+
+	if diags := create(dns, "mail", "300"); len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Detail, "A record ftp requests 600 seconds") {
+		t.Errorf("expected a warning about the record requesting another TTL, got %v", diags)
+	}
+
+	// the zone settings request a TTL as well
+	if _, err := resourceDnsZone().Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "example.com",
+		"ttl":  "3600",
+	}), dns); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if diags := create(dns, "ftp", "600"); len(diags) != 1 || !strings.Contains(diags[0].Detail, "netcup-ccp_dns_zone requests 3600 seconds") {
+		t.Errorf("expected a warning about the zone requesting another TTL, got %v", diags)
+	}
+
+	// the records of another provider configuration are planned separately
+	other := newMemoryDNS()
+	other.addZone("example.com")
+	plan(other, "ftp", "600")
+	if diags := create(other, "ftp", "600"); len(diags) > 0 {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+}
+
+func TestResourceDnsRecord_recordData(t *testing.T) {
+	ctx := context.Background()
+	dns := newMemoryDNS()
+	dns.addZone("example.com")
+
+	config := map[string]interface{}{
+		"domain_name": "example.com",
+		"name":        "_sip._udp",
+		"type":        "SRV",
+		"priority":    "10",
+		"srv": []interface{}{map[string]interface{}{
+			"weight": 5,
+			"port":   5060,
+			"target": "sip.example.com",
+		}},
+	}
+
+	diff, err := resourceDnsRecord().Diff(ctx, nil, terraform.NewResourceConfigRaw(config), dns)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if value := diff.Attributes["value"].New; value != "5 5060 sip.example.com" {
+		t.Errorf("planned value is %q, expected the rendered srv block", value)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDnsRecord().Schema, config)
+	if diags := resourceDnsRecordCreate(ctx, d, dns); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if records := dns.records("example.com"); len(records) != 1 || records[0].Destination != "5 5060 sip.example.com" || records[0].Priority != "10" {
+		t.Fatalf("zone contains %+v, expected the SRV record", records)
+	}
+
+	// the destination changed outside of Terraform is read back into the block
+	record := dns.records("example.com")[0]
+	record.Destination = "5 5061 sip.example.com"
+	if _, err := dns.UpdateDnsRecord(ctx, "example.com", record); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceDnsRecordRead(ctx, d, dns); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if port := d.Get("srv.0.port").(int); port != 5061 {
+		t.Errorf("srv block has port %d, expected 5061", port)
+	}
+
+	config["type"] = "CAA"
+	if _, err := resourceDnsRecord().Diff(ctx, nil, terraform.NewResourceConfigRaw(config), dns); err == nil || !strings.Contains(err.Error(), "the srv block can only be used for SRV records") {
+		t.Errorf("expected the srv block to be rejected for CAA records, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func resourceDnsZone() *schema.Resource {
+	return &schema.Resource{
+		Description: "DNS zone settings of a domain. netcup creates the zone with the domain, so this resource only " +
+			"manages its settings and destroying it leaves the zone unchanged. Settings that are not configured keep their current value.",
+
+		CreateContext: resourceDnsZoneCreate,
+		ReadContext:   resourceDnsZoneRead,
+		UpdateContext: resourceDnsZoneUpdate,
+		DeleteContext: resourceDnsZoneDelete,
+
+		CustomizeDiff: resourceDnsZoneCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDnsZoneImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Domain name of the zone.",
+			},
+			"ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "Default TTL of the zone records in seconds. netcup-ccp_dns_record resources of the zone with a different `ttl` " +
+					"and `update_zone_ttl` overwrite it on every apply and are reported with a warning.",
+			},
+			"refresh": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "SOA refresh interval in seconds.",
+			},
+			"retry": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "SOA retry interval in seconds.",
+			},
+			"expire": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "SOA expire time in seconds.",
+			},
+			"dns_sec_status": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether DNSSEC is enabled for the zone.",
+			},
+			"serial": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Serial number of the zone.",
+			},
+		},
+	}
+}
+
+func resourceDnsZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(d.Get("name").(string))
+	return resourceDnsZoneUpdate(ctx, d, m)
+}
+
+func resourceDnsZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService := m.(client.DNSService)
+
+	zone, err := dnsService.GetDnsZone(ctx, d.Id())
+	var notFound *client.DnsZoneNotFoundError
+	if errors.As(err, &notFound) {
+		// the domain of the zone was deleted or moved to another account outside of Terraform
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", d.Id())
+	d.Set("ttl", zone.TTL)
+	d.Set("refresh", zone.Refresh)
+	d.Set("retry", zone.Retry)
+	d.Set("expire", zone.Expire)
+	d.Set("dns_sec_status", zone.DNSSecStatus)
+	d.Set("serial", zone.Serial)
+
+	return nil
+}
+
+func resourceDnsZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService := m.(client.DNSService)
+
+	// the API requires all settings, unconfigured ones are sent with their current value
+	zone, err := dnsService.GetDnsZone(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ttl, ok := d.GetOk("ttl"); ok {
+		zone.TTL = ttl.(string)
+	}
+	if refresh, ok := d.GetOk("refresh"); ok {
+		zone.Refresh = refresh.(string)
+	}
+	if retry, ok := d.GetOk("retry"); ok {
+		zone.Retry = retry.(string)
+	}
+	if expire, ok := d.GetOk("expire"); ok {
+		zone.Expire = expire.(string)
+	}
+	// GetOk cannot distinguish false from unset
+	if dnsSec, ok := d.GetOkExists("dns_sec_status"); ok {
+		zone.DNSSecStatus = dnsSec.(bool)
+	}
+
+	if _, err := dnsService.UpdateDnsZone(ctx, d.Id(), *zone); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if d.HasChange("ttl") {
+		diags = zoneTTLConflictWarning(m, d.Id(), d.Get("ttl").(string), dnsZoneTTLRequester)
+	}
+	return append(diags, resourceDnsZoneRead(ctx, d, m)...)
+}
+
+// dnsZoneTTLRequester describes the zone settings in the conflicts of a zoneTTLPlan.
+const dnsZoneTTLRequester = "netcup-ccp_dns_zone"
+
+// resourceDnsZoneCustomizeDiff registers a configured or changed ttl in the zoneTTLPlan of the provider configuration,
+// so that records requesting a different TTL for the zone are reported.
+func resourceDnsZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	planner, ok := m.(zoneTTLPlanner)
+	if !ok || !d.NewValueKnown("name") || !d.NewValueKnown("ttl") || d.Get("ttl").(string) == "" {
+		return nil
+	}
+	// an unchanged ttl of an existing zone may just have been read from the zone without being configured
+	if d.Id() != "" && !d.HasChange("ttl") {
+		return nil
+	}
+	if err := planner.zoneTTLPlan().request(d.Get("name").(string), d.Get("ttl").(string), dnsZoneTTLRequester); err != nil {
+		// a plan cannot carry warnings, the conflict is reported again when the zone is applied
+		log.Printf("[WARN] %s", err)
+	}
+	return nil
+}
+
+func resourceDnsZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the zone exists as long as the domain, it is only removed from the state
+	return nil
+}
+
+func resourceDnsZoneImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("name", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceDnsZoneRead_notFound(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDnsZone().Schema, map[string]interface{}{"name": "deleted.example.com"})
+	d.SetId("deleted.example.com")
+
+	if diags := resourceDnsZoneRead(context.Background(), d, newMemoryDNS()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("the ID of a deleted zone was kept")
+	}
+}
+
+func TestAccResourceDnsZone(t *testing.T) {
+	domainName := "settings.example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckDnsZoneTTL(domainName, "3600"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netcup-ccp_dns_zone" "test" {
+  name = %q
+  ttl  = "3600"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_dns_zone.test", "id", domainName),
+					resource.TestCheckResourceAttr("netcup-ccp_dns_zone.test", "ttl", "3600"),
+					resource.TestCheckResourceAttr("netcup-ccp_dns_zone.test", "refresh", "28800"),
+					resource.TestCheckResourceAttr("netcup-ccp_dns_zone.test", "dns_sec_status", "false"),
+					resource.TestCheckResourceAttrSet("netcup-ccp_dns_zone.test", "serial"),
+					testAccCheckDnsZoneTTL(domainName, "3600"),
+				),
+			},
+			{
+				ResourceName:      "netcup-ccp_dns_zone.test",
+				ImportState:       true,
+				ImportStateId:     domainName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckDnsZoneTTL checks the TTL of a zone at the fake API, destroying the resource must not change it.
+func testAccCheckDnsZoneTTL(domainName string, ttl string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		zone, ok := testAccFake.Zone(domainName)
+		if !ok {
+			return fmt.Errorf("zone %s not found", domainName)
+		}
+		if zone.TTL != ttl {
+			return fmt.Errorf("expected TTL %s for zone %s, got %s", ttl, domainName, zone.TTL)
+		}
+		return nil
+	}
+}
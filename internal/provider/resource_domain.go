@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func resourceDomain() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Domain registration",
+
+		CreateContext: resourceDomainCreate,
+		ReadContext:   resourceDomainRead,
+		UpdateContext: resourceDomainUpdate,
+		DeleteContext: resourceDomainDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: mergeSchemas(domainContactsSchema(false), map[string]*schema.Schema{
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"prevent_cancel": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Refuse to cancel the domain on destroy. Must be set to `false` (and applied) before the domain can be destroyed.",
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+// domainContactsSchema returns the schema of the contact handles and nameservers shared by all resources
+// that register a domain with netcup.
+func domainContactsSchema(forceNew bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"owner_handle": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "ID of the handle used as domain owner.",
+		},
+		"admin_handle": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "ID of the handle used as administrative contact.",
+		},
+		"tech_handle": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "ID of the handle used as technical contact.",
+		},
+		"zone_handle": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"billing_handle": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"onsite_handle": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"general_request_handle": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"abuse_contact_handle": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"nameserver": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    8,
+			Description: "Nameservers of the domain. Netcup's nameservers are used if omitted.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"hostname": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"ipv4": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Glue record, only required for nameservers within the domain itself.",
+					},
+					"ipv6": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Glue record, only required for nameservers within the domain itself.",
+					},
+				},
+			},
+		},
+	}
+
+	for _, attribute := range s {
+		attribute.ForceNew = forceNew
+	}
+	return s
+}
+
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for key, attribute := range s {
+			merged[key] = attribute
+		}
+	}
+	return merged
+}
+
+func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainName := d.Get("domain_name").(string)
+	domainService := m.(client.DomainService)
+
+	res, err := domainService.CreateDomain(ctx, domainName, domainContactsFromResourceData(d), nameserversFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the registration was accepted, keep the domain in the state even if waiting for it fails
+	d.SetId(domainName)
+
+	if err := domainService.Await(ctx, res); err != nil {
+		return diag.Errorf("registration of domain %s failed: %s", domainName, err)
+	}
+
+	return resourceDomainRead(ctx, d, m)
+}
+
+func resourceDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainService := m.(client.DomainService)
+
+	domain, err := domainService.GetDomain(ctx, d.Id())
+	var notFound *client.DomainNotFoundError
+	if errors.As(err, &notFound) {
+		// the domain was deleted or moved to another account outside of Terraform
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("domain_name", domain.Name)
+	d.Set("owner_handle", domain.Contacts.OwnerC)
+	d.Set("admin_handle", domain.Contacts.AdminC)
+	d.Set("tech_handle", domain.Contacts.TechC)
+	d.Set("zone_handle", domain.Contacts.ZoneC)
+	d.Set("billing_handle", domain.Contacts.BillingC)
+	d.Set("onsite_handle", domain.Contacts.OnsiteC)
+	d.Set("general_request_handle", domain.Contacts.GeneralRequest)
+	d.Set("abuse_contact_handle", domain.Contacts.AbuseContact)
+	d.Set("state", domain.State)
+	d.Set("created", domain.Created)
+	d.Set("expires", domain.Expires)
+
+	nameservers := make([]interface{}, len(domain.Nameservers))
+	for i, ns := range domain.Nameservers {
+		nameservers[i] = map[string]interface{}{
+			"hostname": ns.Hostname,
+			"ipv4":     ns.IPv4,
+			"ipv6":     ns.IPv6,
+		}
+	}
+	if err := d.Set("nameserver", nameservers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDomainImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// imported domains are protected from cancellation until configured otherwise
+	d.Set("prevent_cancel", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainService := m.(client.DomainService)
+
+	if d.HasChangeExcept("prevent_cancel") {
+		res, err := domainService.UpdateDomain(ctx, d.Id(), domainContactsFromResourceData(d), nameserversFromResourceData(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := domainService.Await(ctx, res); err != nil {
+			return diag.Errorf("update of domain %s failed: %s", d.Id(), err)
+		}
+	}
+
+	return resourceDomainRead(ctx, d, m)
+}
+
+func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("prevent_cancel").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Domain cancellation prevented",
+			Detail:   "Domain " + d.Id() + " has prevent_cancel set. Set prevent_cancel = false and apply before destroying this resource, or remove it from the state to keep the domain.",
+		}}
+	}
+
+	domainService := m.(client.DomainService)
+
+	res, err := domainService.CancelDomain(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := domainService.Await(ctx, res); err != nil {
+		return diag.Errorf("cancellation of domain %s failed: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func domainContactsFromResourceData(d *schema.ResourceData) client.DomainContacts {
+	return client.DomainContacts{
+		OwnerC:         d.Get("owner_handle").(string),
+		AdminC:         d.Get("admin_handle").(string),
+		TechC:          d.Get("tech_handle").(string),
+		ZoneC:          d.Get("zone_handle").(string),
+		BillingC:       d.Get("billing_handle").(string),
+		OnsiteC:        d.Get("onsite_handle").(string),
+		GeneralRequest: d.Get("general_request_handle").(string),
+		AbuseContact:   d.Get("abuse_contact_handle").(string),
+	}
+}
+
+func nameserversFromResourceData(d *schema.ResourceData) client.NameserverSet {
+	entries := d.Get("nameserver").([]interface{})
+	nameservers := make(client.NameserverSet, 0, len(entries))
+	for _, entry := range entries {
+		ns := entry.(map[string]interface{})
+		nameservers = append(nameservers, client.Nameserver{
+			Hostname: ns["hostname"].(string),
+			IPv4:     ns["ipv4"].(string),
+			IPv6:     ns["ipv6"].(string),
+		})
+	}
+	return nameservers
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestResourceDomainRead_notFound(t *testing.T) {
+	ccpClient, _ := newTestClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{"domain_name": "deleted.de"})
+	d.SetId("deleted.de")
+
+	if diags := resourceDomainRead(context.Background(), d, ccpClient); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("the ID of a deleted domain was kept")
+	}
+}
+
+// pendingDomains accepts every registration and fails to await its completion.
+type pendingDomains struct {
+	client.DomainService
+}
+
+func (pendingDomains) CreateDomain(context.Context, string, client.DomainContacts, client.NameserverSet) (*client.ResponseBody, error) {
+	return &client.ResponseBody{Status: "pending", ServerRequestId: "REQUEST_ID"}, nil
+}
+
+func (pendingDomains) Await(context.Context, *client.ResponseBody) error {
+	return errors.New("context deadline exceeded")
+}
+
+func TestResourceDomainCreate_awaitFails(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{
+		"domain_name":  "example.de",
+		"owner_handle": "1",
+		"admin_handle": "2",
+		"tech_handle":  "3",
+	})
+
+	diags := resourceDomainCreate(context.Background(), d, pendingDomains{})
+
+	if !diags.HasError() {
+		t.Fatalf("expected the failed wait to be reported")
+	}
+	if d.Id() != "example.de" {
+		t.Errorf("the accepted registration was not kept in the state, got ID %q", d.Id())
+	}
+}
+
+func TestAccResourceDomain(t *testing.T) {
+	domainName := "registration-test.de"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckDomainDestroyed(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDomain(domainName, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "id", domainName),
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "tech_handle", "3"),
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "nameserver.#", "2"),
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "nameserver.0.hostname", "ns1.example.net"),
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "state", "active"),
+					testAccCheckDomainTechHandle(domainName, "3"),
+				),
+			},
+			// update in place
+			{
+				Config: testAccResourceDomain(domainName, "4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_domain.test", "tech_handle", "4"),
+					testAccCheckDomainTechHandle(domainName, "4"),
+				),
+			},
+			// import
+			{
+				ResourceName:            "netcup-ccp_domain.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prevent_cancel"},
+			},
+			// drift: the tech contact is changed outside of Terraform and restored
+			{
+				PreConfig: func() {
+					domain, _ := testAccFake.GetDomain(domainName)
+					domain.Contacts = map[string]string{"ownerc": "1", "adminc": "2", "techc": "99"}
+					testAccFake.AddDomain(domain)
+				},
+				Config: testAccResourceDomain(domainName, "4"),
+				Check:  testAccCheckDomainTechHandle(domainName, "4"),
+			},
+		},
+	})
+}
+
+func TestResourceDomainDelete_preventCancel(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{
+		"domain_name":  "example.de",
+		"owner_handle": "1",
+		"admin_handle": "2",
+		"tech_handle":  "3",
+	})
+	d.SetId("example.de")
+
+	// the client is never used, deletion is refused before any request is sent
+	diags := resourceDomainDelete(context.Background(), d, nil)
+
+	if !diags.HasError() || diags[0].Summary != "Domain cancellation prevented" {
+		t.Errorf("expected cancellation to be prevented, got %v", diags)
+	}
+}
+
+func testAccCheckDomainTechHandle(domainName string, techHandle string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		domain, ok := testAccFake.GetDomain(domainName)
+		if !ok {
+			return fmt.Errorf("domain %s is not registered", domainName)
+		}
+		if domain.Contacts["techc"] != techHandle {
+			return fmt.Errorf("domain %s has tech contact %q, expected %q", domainName, domain.Contacts["techc"], techHandle)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDomainDestroyed(domainName string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if _, ok := testAccFake.GetDomain(domainName); ok {
+			return fmt.Errorf("domain %s is still registered", domainName)
+		}
+		return nil
+	}
+}
+
+func testAccResourceDomain(domainName string, techHandle string) string {
+	return fmt.Sprintf(`
+resource "netcup-ccp_domain" "test" {
+  domain_name    = %q
+  owner_handle   = "1"
+  admin_handle   = "2"
+  tech_handle    = %q
+  prevent_cancel = false
+
+  nameserver {
+    hostname = "ns1.example.net"
+  }
+  nameserver {
+    hostname = "ns2.example.net"
+  }
+}
+`, domainName, techHandle)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+// transferPending is the state of a domain transfer that has been requested but not completed yet.
+const transferPending = "pending"
+
+func resourceDomainTransfer() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Transfer of a domain from another registrar to netcup. Creating this resource only requests the transfer, which may take several days; `state` is `" + transferPending + "` until the domain belongs to the account. Destroying this resource only removes it from the state.",
+
+		CreateContext: resourceDomainTransferCreate,
+		ReadContext:   resourceDomainTransferRead,
+		DeleteContext: resourceDomainTransferDelete,
+
+		Schema: mergeSchemas(domainContactsSchema(true), map[string]*schema.Schema{
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auth_code": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Auth code issued by the losing registrar.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "`" + transferPending + "` while the transfer is processed, the state of the domain afterwards.",
+			},
+		}),
+	}
+}
+
+func resourceDomainTransferCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainName := d.Get("domain_name").(string)
+	domainService := m.(client.DomainService)
+
+	res, err := domainService.TransferDomain(ctx, domainName, d.Get("auth_code").(string), domainContactsFromResourceData(d), nameserversFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// transfers take up to several days, their progress is reported by Read instead of waiting for them
+	d.SetId(domainName)
+	if res.IsPending() {
+		d.Set("state", transferPending)
+	}
+
+	return resourceDomainTransferRead(ctx, d, m)
+}
+
+func resourceDomainTransferRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainService := m.(client.DomainService)
+
+	domain, err := domainService.GetDomain(ctx, d.Id())
+	var notFound *client.DomainNotFoundError
+	if errors.As(err, &notFound) {
+		if d.Get("state").(string) == transferPending {
+			// the domain belongs to the account once the transfer is completed
+			return nil
+		}
+		// the transferred domain was deleted or moved to another account outside of Terraform
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("state", domain.State)
+
+	return nil
+}
+
+func resourceDomainTransferDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// a completed transfer cannot be undone, the domain stays with netcup
+	return nil
+}
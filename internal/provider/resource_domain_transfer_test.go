@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestResourceDomainTransferRead_notFound(t *testing.T) {
+	ccpClient, _ := newTestClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceDomainTransfer().Schema, map[string]interface{}{"domain_name": "transferred.de"})
+	d.SetId("transferred.de")
+
+	if diags := resourceDomainTransferRead(context.Background(), d, ccpClient); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("the ID of a transfer of a deleted domain was kept")
+	}
+}
+
+// pendingTransfers accepts every transfer without ever completing it. Await is not implemented, a call panics.
+type pendingTransfers struct {
+	client.DomainService
+}
+
+func (pendingTransfers) TransferDomain(context.Context, string, string, client.DomainContacts, client.NameserverSet) (*client.ResponseBody, error) {
+	return &client.ResponseBody{Status: "pending", ServerRequestId: "REQUEST_ID"}, nil
+}
+
+func (pendingTransfers) GetDomain(_ context.Context, domainName string) (*client.Domain, error) {
+	return nil, &client.DomainNotFoundError{DomainName: domainName}
+}
+
+func TestResourceDomainTransfer_pending(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDomainTransfer().Schema, map[string]interface{}{
+		"domain_name":  "transferred.de",
+		"auth_code":    "secret",
+		"owner_handle": "1",
+		"admin_handle": "2",
+		"tech_handle":  "3",
+	})
+
+	if diags := resourceDomainTransferCreate(context.Background(), d, pendingTransfers{}); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "transferred.de" || d.Get("state") != transferPending {
+		t.Fatalf("expected a pending transfer of transferred.de, got ID %q and state %q", d.Id(), d.Get("state"))
+	}
+
+	if diags := resourceDomainTransferRead(context.Background(), d, pendingTransfers{}); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "transferred.de" || d.Get("state") != transferPending {
+		t.Errorf("a pending transfer was not kept, got ID %q and state %q", d.Id(), d.Get("state"))
+	}
+}
+
+func TestAccResourceDomainTransfer(t *testing.T) {
+	domainName := "transfer-test.de"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netcup-ccp_domain_transfer" "test" {
+  domain_name  = %q
+  auth_code    = "secret"
+  owner_handle = "1"
+  admin_handle = "2"
+  tech_handle  = "3"
+}
+`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_domain_transfer.test", "id", domainName),
+					// the fake completes transfers right away
+					resource.TestCheckResourceAttr("netcup-ccp_domain_transfer.test", "state", "active"),
+				),
+			},
+		},
+		// destroying a transfer leaves the domain with netcup
+		CheckDestroy: func(*terraform.State) error {
+			if _, ok := testAccFake.GetDomain(domainName); !ok {
+				return fmt.Errorf("transferred domain %s was removed", domainName)
+			}
+			return nil
+		},
+	})
+}
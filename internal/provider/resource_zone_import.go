@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func resourceZoneImport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Records of a DNS zone managed from an RFC 1035 zone file, e.g. to migrate a zone from another DNS provider. " +
+			"The SOA record, NS records at the zone apex and records of types netcup does not support are ignored with a warning.",
+
+		CreateContext: resourceZoneImportCreate,
+		ReadContext:   resourceZoneImportRead,
+		UpdateContext: resourceZoneImportUpdate,
+		DeleteContext: resourceZoneImportDelete,
+		CustomizeDiff: resourceZoneImportCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Domain name of the zone.",
+			},
+			"zone_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Content of the zone file. Relative names are relative to `domain_name` unless changed with `$ORIGIN`.",
+			},
+			"purge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete all records of the zone that are not in the zone file, including records not created by this resource.",
+			},
+			"check_existing_records": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Fail the plan if the records of the zone file conflict with the existing records of the zone, e.g. a CNAME " +
+					"record with other records of its hostname. Records deleted in the same apply by other resources still count as " +
+					"existing, set this to `false` to only check the records of the zone file against each other.",
+			},
+			"records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Records managed by this resource.",
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Records of the zone file that were ignored.",
+			},
+		},
+	}
+}
+
+func resourceZoneImportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(d.Get("domain_name").(string))
+	return reconcileZone(ctx, d, m.(client.DNSService))
+}
+
+func resourceZoneImportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService := m.(client.DNSService)
+
+	existing, err := dnsService.GetDnsRecords(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// records deleted or changed outside of Terraform are dropped from the state and restored on the next apply
+	var managed []client.DnsRecord
+	for _, record := range zoneImportRecords(d) {
+		for _, e := range existing {
+			if e.Id == record.Id && e.Matches(record) {
+				managed = append(managed, e)
+				break
+			}
+		}
+	}
+
+	if err := d.Set("records", flattenZoneImportRecords(managed)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceZoneImportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return reconcileZone(ctx, d, m.(client.DNSService))
+}
+
+func resourceZoneImportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dnsService := m.(client.DNSService)
+
+	existing, err := dnsService.GetDnsRecords(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var deletions []client.DnsRecord
+	for _, record := range zoneImportRecords(d) {
+		for _, e := range existing {
+			if e.Id == record.Id {
+				e.DeleteRecord = true
+				deletions = append(deletions, e)
+			}
+		}
+	}
+
+	if len(deletions) > 0 {
+		if _, err := dnsService.UpdateDnsRecords(ctx, d.Id(), deletions); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// resourceZoneImportCustomizeDiff validates the zone file at plan time and plans an update if the managed records
+// no longer match it, e.g. because records were deleted outside of Terraform.
+func resourceZoneImportCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("zone_file") {
+		return nil
+	}
+	zoneFile, err := client.ParseZoneFile(strings.NewReader(d.Get("zone_file").(string)), d.Get("domain_name").(string))
+	if err != nil {
+		return fmt.Errorf("invalid zone_file: %w", err)
+	}
+
+	var managed []client.DnsRecord
+	for _, r := range d.Get("records").([]interface{}) {
+		managed = append(managed, expandZoneImportRecord(r.(map[string]interface{})))
+	}
+
+	if err := checkZoneImportConflicts(ctx, d, m, zoneFile.Records, managed); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("zone_file") || !sameRecords(zoneFile.Records, managed) {
+		if err := d.SetNewComputed("records"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("warnings")
+	}
+	return nil
+}
+
+// checkZoneImportConflicts checks the records the zone will have after reconciling it with the zone file for
+// conflicts, see client.CheckDnsRecords. Without access to the existing records of the zone or if
+// check_existing_records is false, only the records of the zone file are checked.
+func checkZoneImportConflicts(ctx context.Context, d *schema.ResourceDiff, m interface{}, desired []client.DnsRecord, managed []client.DnsRecord) error {
+	domainName := d.Get("domain_name").(string)
+	records := desired
+
+	if dnsService, ok := m.(client.DNSService); ok && d.NewValueKnown("domain_name") && d.Get("check_existing_records").(bool) {
+		existing, err := dnsService.GetDnsRecords(ctx, domainName)
+		if err != nil {
+			log.Printf("[DEBUG] Checking zone file records only, unable to retrieve records of %s: %s", domainName, err)
+		} else {
+			managedIds := map[string]bool{}
+			for _, record := range managed {
+				managedIds[record.Id] = true
+			}
+			purge := d.Get("purge").(bool)
+			changes := client.DnsRecordChanges(existing, desired, func(record client.DnsRecord) bool {
+				return purge || managedIds[record.Id]
+			})
+			records = reconciledRecords(existing, changes)
+		}
+	}
+
+	return dnsRecordConflictsError(domainName, client.CheckDnsRecords(domainName, records))
+}
+
+// reconciledRecords returns the records of a zone after applying changes as returned by client.DnsRecordChanges.
+func reconciledRecords(existing []client.DnsRecord, changes []client.DnsRecord) []client.DnsRecord {
+	deleted := map[string]bool{}
+	var records []client.DnsRecord
+	for _, change := range changes {
+		if change.DeleteRecord {
+			deleted[change.Id] = true
+		} else {
+			records = append(records, change)
+		}
+	}
+	for _, record := range existing {
+		if !deleted[record.Id] {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// reconcileZone creates the records of the zone file that do not exist yet and deletes the records managed by the
+// resource that are no longer in it, or all other records if purge is set, in a single request.
+func reconcileZone(ctx context.Context, d *schema.ResourceData, dnsService client.DNSService) diag.Diagnostics {
+	var diags diag.Diagnostics
+	domainName := d.Id()
+
+	zoneFile, err := client.ParseZoneFile(strings.NewReader(d.Get("zone_file").(string)), domainName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, warning := range zoneFile.Warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Zone file record ignored",
+			Detail:   warning,
+		})
+	}
+
+	existing, err := dnsService.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	managedIds := map[string]bool{}
+	for _, record := range zoneImportRecords(d) {
+		managedIds[record.Id] = true
+	}
+	purge := d.Get("purge").(bool)
+
+	changes := client.DnsRecordChanges(existing, zoneFile.Records, func(record client.DnsRecord) bool {
+		return purge || managedIds[record.Id]
+	})
+
+	records := existing
+	if len(changes) > 0 {
+		records, err = dnsService.UpdateDnsRecords(ctx, domainName, changes)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	managed, err := client.FindDnsRecords(records, zoneFile.Records)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set("records", flattenZoneImportRecords(managed)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set("warnings", zoneFile.Warnings); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// sameRecords reports whether both lists contain the same records, ignoring order and IDs.
+func sameRecords(a []client.DnsRecord, b []client.DnsRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+outer:
+	for _, r := range a {
+		for i := range b {
+			if !used[i] && b[i].Matches(r) {
+				used[i] = true
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func zoneImportRecords(d *schema.ResourceData) []client.DnsRecord {
+	var records []client.DnsRecord
+	for _, r := range d.Get("records").([]interface{}) {
+		records = append(records, expandZoneImportRecord(r.(map[string]interface{})))
+	}
+	return records
+}
+
+func expandZoneImportRecord(r map[string]interface{}) client.DnsRecord {
+	return client.DnsRecord{
+		Id:          r["id"].(string),
+		Hostname:    r["name"].(string),
+		Type:        r["type"].(string),
+		Priority:    r["priority"].(string),
+		Destination: r["value"].(string),
+	}
+}
+
+func flattenZoneImportRecords(records []client.DnsRecord) []interface{} {
+	flattened := make([]interface{}, len(records))
+	for i, record := range records {
+		flattened[i] = map[string]interface{}{
+			"id":       record.Id,
+			"name":     record.Hostname,
+			"type":     record.Type,
+			"priority": record.Priority,
+			"value":    record.Destination,
+		}
+	}
+	return flattened
+}
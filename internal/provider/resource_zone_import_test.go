@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+)
+
+func TestAccResourceZoneImport(t *testing.T) {
+	domainName := "import.example.com"
+	var unmanagedId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFake.AddZone(domainName)
+			unmanaged, err := testAccFake.AddRecord(domainName, ccpfake.Record{Hostname: "unmanaged", Type: "A", Destination: "9.9.9.9"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			unmanagedId = unmanaged.Id
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckZoneImportDestroyed(domainName, &unmanagedId),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceZoneImport(domainName, `
+@	IN	SOA	ns1.other.net. hostmaster.other.net. 1 3600 900 604800 300
+@	IN	A	1.2.3.4
+@	IN	MX	10 mail
+www	IN	CNAME	@
+host	IN	HINFO	"PC" "Linux"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "records.#", "3"),
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "records.1.type", "MX"),
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "records.1.priority", "10"),
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "records.1.value", "mail.import.example.com"),
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "warnings.#", "2"),
+					testAccCheckZoneRecordCount(domainName, 4),
+				),
+			},
+			// records removed from the zone file are deleted, unmanaged records are kept
+			{
+				Config: testAccResourceZoneImport(domainName, `
+@	IN	A	1.2.3.4
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "records.#", "1"),
+					resource.TestCheckResourceAttr("netcup-ccp_zone_import.test", "warnings.#", "0"),
+					testAccCheckZoneRecordCount(domainName, 2),
+				),
+			},
+			// drift: a managed record is deleted outside of Terraform and restored
+			{
+				PreConfig: func() {
+					for _, record := range testAccFake.Records(domainName) {
+						if record.Hostname == "@" {
+							if err := testAccFake.RemoveRecord(domainName, record.Id); err != nil {
+								t.Fatal(err)
+							}
+						}
+					}
+				},
+				Config: testAccResourceZoneImport(domainName, `
+@	IN	A	1.2.3.4
+`),
+				Check: testAccCheckZoneRecordCount(domainName, 2),
+			},
+			{
+				Config:      testAccResourceZoneImport(domainName, "@ IN MX mail\n"),
+				ExpectError: regexp.MustCompile("MX record requires 2 fields"),
+			},
+		},
+	})
+}
+
+func testAccCheckZoneRecordCount(domainName string, count int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if records := testAccFake.Records(domainName); len(records) != count {
+			return fmt.Errorf("zone %s has %d records, expected %d", domainName, len(records), count)
+		}
+		return nil
+	}
+}
+
+// testAccCheckZoneImportDestroyed verifies that only the record not created by the resource is left in the zone.
+func testAccCheckZoneImportDestroyed(domainName string, unmanagedId *string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		records := testAccFake.Records(domainName)
+		if len(records) != 1 || records[0].Id != *unmanagedId {
+			return fmt.Errorf("zone %s still contains %d records", domainName, len(records))
+		}
+		return nil
+	}
+}
+
+func testAccResourceZoneImport(domainName string, zoneFile string) string {
+	return fmt.Sprintf(`
+resource "netcup-ccp_zone_import" "test" {
+  domain_name = %q
+  zone_file   = <<-EOT
+%s
+EOT
+}
+`, domainName, zoneFile)
+}
+
+func TestResourceZoneImportCustomizeDiff_conflicts(t *testing.T) {
+	dns := newMemoryDNS()
+	dns.addZone("example.com", client.DnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+
+	for _, tc := range []struct {
+		name         string
+		purge        bool
+		skipExisting bool
+		conflict     bool
+	}{
+		{name: "existing records are kept", conflict: true},
+		{name: "existing records are purged", purge: true},
+		{name: "existing records are not checked", skipExisting: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resourceZoneImport().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"domain_name":            "example.com",
+				"zone_file":              "www 3600 IN CNAME example.org.\n",
+				"purge":                  tc.purge,
+				"check_existing_records": !tc.skipExisting,
+			}), dns)
+
+			if conflict := err != nil && strings.Contains(err.Error(), "a hostname with a CNAME record cannot have other records"); conflict != tc.conflict {
+				t.Errorf("diff returned %v, expected a conflict: %t", err, tc.conflict)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Package acme solves ACME DNS-01 challenges with DNS zones hosted by netcup, outside of Terraform.
+//
+// DNSProvider implements the challenge.Provider and challenge.ProviderTimeout interfaces of lego
+// (github.com/go-acme/lego), so it can be passed to a lego client directly:
+//
+//	provider, err := acme.NewDNSProvider()
+//	if err != nil {
+//		return err
+//	}
+//	err = legoClient.Challenge.SetDNS01Provider(provider)
+//
+// Tools that compute the challenge record themselves, such as cert-manager webhooks, use PresentRecord and
+// CleanUpRecord instead.
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/client"
+	"github.com/rincedd/terraform-provider-netcup-ccp/pkg/credentials"
+)
+
+// Environment variables read by NewDNSProvider and NewDefaultConfig in addition to the credential variables of the
+// provider.
+const (
+	EnvEndpoint           = "NETCUP_CCP_ENDPOINT"
+	EnvTTL                = "NETCUP_TTL"
+	EnvPropagationTimeout = "NETCUP_PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = "NETCUP_POLLING_INTERVAL"
+)
+
+// Defaults of Config.
+const (
+	DefaultPropagationTimeout = 15 * time.Minute
+	DefaultPollingInterval    = 30 * time.Second
+)
+
+// Config configures a DNSProvider.
+type Config struct {
+	CustomerNumber string
+	APIKey         string
+	APIPassword    string
+
+	// Endpoint is the URL of the CCP API. Defaults to the public endpoint.
+	Endpoint string
+	// HTTPClient sends the requests to the CCP API. Defaults to a client with a timeout of 10 seconds.
+	HTTPClient *http.Client
+
+	// TTL in seconds lowers the default TTL of the zone to at most this value before a challenge record is created.
+	// netcup does not support TTLs of single records. The previous TTL is restored when the last challenge of the zone
+	// presented by the DNSProvider is cleaned up, so Present and CleanUp must be called on the same DNSProvider. Zero
+	// leaves the zone unchanged.
+	TTL int
+	// PropagationTimeout limits the time to wait until a challenge record is active and, with QueryNameservers, served
+	// by the nameservers. It is also returned by Timeout for the propagation check of lego.
+	PropagationTimeout time.Duration
+	// PollingInterval is the time between two checks of a challenge record.
+	PollingInterval time.Duration
+	// QueryNameservers waits in Present until the Nameservers answer with the challenge record.
+	QueryNameservers bool
+	// Nameservers are queried as "host:port" if QueryNameservers is set. Defaults to netcup's nameservers.
+	Nameservers []string
+}
+
+// DNSProvider publishes ACME DNS-01 challenge records in netcup zones.
+type DNSProvider struct {
+	config *Config
+	client *client.CCPClient
+
+	mu sync.Mutex
+	// lowered maps the domain names of zones whose TTL was lowered by Present to their previous TTL
+	lowered map[string]*loweredTTL
+}
+
+// loweredTTL is the TTL of a zone before Present lowered it and the number of challenges presented in the zone since.
+type loweredTTL struct {
+	previous   string
+	challenges int
+}
+
+// NewDefaultConfig returns a configuration without credentials, reading the endpoint, TTL, propagation timeout and
+// polling interval (in seconds) from the environment.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Endpoint:           envOrDefault(EnvEndpoint, client.HostURL),
+		TTL:                envSeconds(EnvTTL, 0),
+		PropagationTimeout: time.Duration(envSeconds(EnvPropagationTimeout, int(DefaultPropagationTimeout/time.Second))) * time.Second,
+		PollingInterval:    time.Duration(envSeconds(EnvPollingInterval, int(DefaultPollingInterval/time.Second))) * time.Second,
+		Nameservers:        client.NetcupNameservers,
+	}
+}
+
+// NewDNSProvider returns a DNSProvider configured from the environment. Credentials are resolved like in the
+// Terraform provider: all of them are taken from NETCUP_CUSTOMER_NUMBER, NETCUP_CCP_API_KEY, NETCUP_CCP_API_PASSWORD
+// and NETCUP_CCP_API_PASSWORD_FILE if any of these is set, otherwise from the profile NETCUP_PROFILE of the shared
+// credentials file NETCUP_CREDENTIALS_FILE.
+func NewDNSProvider() (*DNSProvider, error) {
+	creds, err := credentials.Resolve(context.Background(), credentials.Sources{
+		Explicit: credentials.Credentials{
+			CustomerNumber: os.Getenv("NETCUP_CUSTOMER_NUMBER"),
+			APIKey:         os.Getenv("NETCUP_CCP_API_KEY"),
+			APIPassword:    os.Getenv("NETCUP_CCP_API_PASSWORD"),
+		},
+		PasswordFile: os.Getenv("NETCUP_CCP_API_PASSWORD_FILE"),
+		File:         os.Getenv("NETCUP_CREDENTIALS_FILE"),
+		Profile:      os.Getenv("NETCUP_PROFILE"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("netcup: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.CustomerNumber = creds.CustomerNumber
+	config.APIKey = creds.APIKey
+	config.APIPassword = creds.APIPassword
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig returns a DNSProvider for the given configuration. Zero durations are replaced by the
+// defaults. No request is sent before the first challenge.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("netcup: the configuration of the DNS provider is nil")
+	}
+	if config.CustomerNumber == "" || config.APIKey == "" || config.APIPassword == "" {
+		return nil, errors.New("netcup: customer number, API key and API password are required")
+	}
+
+	c := *config
+	if c.Endpoint == "" {
+		c.Endpoint = client.HostURL
+	}
+	if c.PropagationTimeout <= 0 {
+		c.PropagationTimeout = DefaultPropagationTimeout
+	}
+	if c.PollingInterval <= 0 {
+		c.PollingInterval = DefaultPollingInterval
+	}
+	if len(c.Nameservers) == 0 {
+		c.Nameservers = client.NetcupNameservers
+	}
+
+	opts := []client.Option{
+		client.WithEndpoint(c.Endpoint),
+		client.WithPollInterval(c.PollingInterval),
+		client.WithLazyLogin(),
+	}
+	if c.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(c.HTTPClient))
+	}
+	ccpClient, err := client.NewCCPClient(c.CustomerNumber, c.APIKey, c.APIPassword, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("netcup: %w", err)
+	}
+	ccpClient.UserAgent = "netcup-ccp-acme"
+
+	return &DNSProvider{config: &c, client: ccpClient, lowered: map[string]*loweredTTL{}}, nil
+}
+
+// ChallengeRecord returns the fully qualified name (with trailing dot) and value of the TXT record that validates
+// domain with the key authorization keyAuth, as defined in RFC 8555, section 8.4.
+func ChallengeRecord(domain string, keyAuth string) (string, string) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	fqdn := "_acme-challenge." + strings.TrimPrefix(strings.TrimSuffix(domain, "."), "*.") + "."
+	return fqdn, base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// Present creates the TXT record for the challenge of domain and waits until it is active.
+func (p *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+	return p.PresentRecord(context.Background(), fqdn, value)
+}
+
+// CleanUp deletes the TXT record created by Present and restores the zone TTL lowered by it, see Config.TTL.
+func (p *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+	return p.CleanUpRecord(context.Background(), fqdn, value)
+}
+
+// Timeout returns the propagation timeout and polling interval for the propagation check of lego.
+func (p *DNSProvider) Timeout() (time.Duration, time.Duration) {
+	return p.config.PropagationTimeout, p.config.PollingInterval
+}
+
+// PresentRecord creates a TXT record with the given fully qualified name and value in the zone of the account that
+// contains it and waits until it is active. An existing identical record is reused.
+func (p *DNSProvider) PresentRecord(ctx context.Context, fqdn string, value string) error {
+	domainName, hostname, err := client.FindZone(ctx, p.client, fqdn)
+	if err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+
+	if p.config.TTL > 0 {
+		if err := p.lowerZoneTTL(ctx, domainName); err != nil {
+			return fmt.Errorf("netcup: %w", err)
+		}
+	}
+
+	desired := client.NewDnsRecord{Hostname: hostname, Type: "TXT", Destination: value}
+	record, err := p.findRecord(ctx, domainName, desired)
+	if err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+	if record == nil {
+		if record, err = p.client.CreateDnsRecord(ctx, domainName, desired); err != nil {
+			return fmt.Errorf("netcup: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.PropagationTimeout)
+	defer cancel()
+
+	if _, err := client.PollDnsRecordActive(ctx, p.client, p.config.PollingInterval, domainName, record.Id); err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+	if p.config.QueryNameservers {
+		if err := client.WaitForDnsRecordResolvable(ctx, p.config.Nameservers, p.config.PollingInterval, domainName, *record); err != nil {
+			return fmt.Errorf("netcup: %w", err)
+		}
+	}
+	return nil
+}
+
+// CleanUpRecord deletes the TXT record with the given fully qualified name and value and restores the zone TTL once
+// the last challenge of the zone is cleaned up. A missing record is not an error.
+func (p *DNSProvider) CleanUpRecord(ctx context.Context, fqdn string, value string) error {
+	domainName, hostname, err := client.FindZone(ctx, p.client, fqdn)
+	if err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+
+	record, err := p.findRecord(ctx, domainName, client.NewDnsRecord{Hostname: hostname, Type: "TXT", Destination: value})
+	if err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+	if record != nil {
+		if err := p.client.DeleteDnsRecord(ctx, domainName, *record); err != nil {
+			return fmt.Errorf("netcup: %w", err)
+		}
+	}
+
+	if err := p.restoreZoneTTL(ctx, domainName); err != nil {
+		return fmt.Errorf("netcup: %w", err)
+	}
+	return nil
+}
+
+func (p *DNSProvider) findRecord(ctx context.Context, domainName string, desired client.NewDnsRecord) (*client.DnsRecord, error) {
+	records, err := p.client.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if desired.Matches(record) {
+			return &record, nil
+		}
+	}
+	return nil, nil
+}
+
+// lowerZoneTTL lowers the TTL of the zone to the configured TTL and remembers the previous one for restoreZoneTTL.
+func (p *DNSProvider) lowerZoneTTL(ctx context.Context, domainName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if lowered, ok := p.lowered[domainName]; ok {
+		lowered.challenges++
+		return nil
+	}
+
+	zone, err := p.client.GetDnsZone(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	if ttl, err := strconv.Atoi(zone.TTL); err == nil && ttl <= p.config.TTL {
+		return nil
+	}
+	previous := zone.TTL
+	zone.TTL = strconv.Itoa(p.config.TTL)
+	if _, err := p.client.UpdateDnsZone(ctx, domainName, *zone); err != nil {
+		return err
+	}
+	p.lowered[domainName] = &loweredTTL{previous: previous, challenges: 1}
+	return nil
+}
+
+// restoreZoneTTL restores the TTL of a zone lowered by lowerZoneTTL once all challenges presented in the zone are
+// cleaned up. A TTL changed by others in the meantime is left unchanged.
+func (p *DNSProvider) restoreZoneTTL(ctx context.Context, domainName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lowered, ok := p.lowered[domainName]
+	if !ok {
+		return nil
+	}
+	if lowered.challenges--; lowered.challenges > 0 {
+		return nil
+	}
+	delete(p.lowered, domainName)
+
+	zone, err := p.client.GetDnsZone(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	if zone.TTL != strconv.Itoa(p.config.TTL) {
+		return nil
+	}
+	zone.TTL = lowered.previous
+	_, err = p.client.UpdateDnsZone(ctx, domainName, *zone)
+	return err
+}
+
+func envOrDefault(key string, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func envSeconds(key string, defaultValue int) int {
+	if seconds, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return seconds
+	}
+	return defaultValue
+}
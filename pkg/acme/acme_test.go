@@ -0,0 +1,105 @@
+package acme
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChallengeRecord(t *testing.T) {
+	Convey("The challenge record is derived from domain and key authorization", t, func() {
+		digest := sha256.Sum256([]byte("token.thumbprint"))
+		expected := base64.RawURLEncoding.EncodeToString(digest[:])
+
+		fqdn, value := ChallengeRecord("www.domain.com", "token.thumbprint")
+		So(fqdn, ShouldEqual, "_acme-challenge.www.domain.com.")
+		So(value, ShouldEqual, expected)
+		So(value, ShouldNotContainSubstring, "=")
+
+		fqdn, _ = ChallengeRecord("*.domain.com.", "token.thumbprint")
+		So(fqdn, ShouldEqual, "_acme-challenge.domain.com.")
+	})
+}
+
+func TestDNSProvider(t *testing.T) {
+	Convey("Given a DNS provider for a fake CCP API", t, func() {
+		fake := ccpfake.New("12345", "apikey", "apipassword")
+		fake.AddDomain(ccpfake.Domain{Name: "domain.com"})
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		config := NewDefaultConfig()
+		config.CustomerNumber = "12345"
+		config.APIKey = "apikey"
+		config.APIPassword = "apipassword"
+		config.Endpoint = srv.URL
+		config.PollingInterval = time.Millisecond
+		config.PropagationTimeout = time.Second
+
+		Convey("Present creates an active record and CleanUp deletes it", func() {
+			fake.ActivateAfter = 2
+			provider, err := NewDNSProviderConfig(config)
+			So(err, ShouldBeNil)
+
+			So(provider.Present("*.www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			records := fake.Records("domain.com")
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Hostname, ShouldEqual, "_acme-challenge.www")
+			So(records[0].Type, ShouldEqual, "TXT")
+			So(records[0].State, ShouldEqual, "yes")
+
+			// presenting the same challenge again reuses the record
+			So(provider.Present("www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			So(fake.Records("domain.com"), ShouldHaveLength, 1)
+
+			So(provider.CleanUp("www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			So(fake.Records("domain.com"), ShouldBeEmpty)
+			So(provider.CleanUp("www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+		})
+
+		Convey("the zone TTL is lowered to the configured TTL until the last challenge is cleaned up", func() {
+			config.TTL = 300
+			provider, err := NewDNSProviderConfig(config)
+			So(err, ShouldBeNil)
+
+			So(provider.Present("domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			So(provider.Present("www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			zone, _ := fake.Zone("domain.com")
+			So(zone.TTL, ShouldEqual, "300")
+
+			So(provider.CleanUp("domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			zone, _ = fake.Zone("domain.com")
+			So(zone.TTL, ShouldEqual, "300")
+
+			So(provider.CleanUp("www.domain.com", "token", "token.thumbprint"), ShouldBeNil)
+			zone, _ = fake.Zone("domain.com")
+			So(zone.TTL, ShouldEqual, "86400")
+		})
+
+		Convey("challenges for domains of other accounts fail", func() {
+			provider, err := NewDNSProviderConfig(config)
+			So(err, ShouldBeNil)
+
+			So(provider.Present("other.com", "token", "token.thumbprint"), ShouldNotBeNil)
+		})
+
+		Convey("the timeouts are reported to lego", func() {
+			provider, err := NewDNSProviderConfig(config)
+			So(err, ShouldBeNil)
+
+			timeout, interval := provider.Timeout()
+			So(timeout, ShouldEqual, time.Second)
+			So(interval, ShouldEqual, time.Millisecond)
+		})
+	})
+
+	Convey("Credentials are required", t, func() {
+		_, err := NewDNSProviderConfig(NewDefaultConfig())
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	listDomainsResponse struct {
+		ResponseBody
+		ResponseData []Domain `json:"responsedata"`
+	}
+)
+
+// DomainNotAllowedError is returned if a change to a domain is refused by the allowed or forbidden domains of the
+// client.
+type DomainNotAllowedError struct {
+	DomainName     string
+	CustomerNumber string
+}
+
+func (e *DomainNotAllowedError) Error() string {
+	return fmt.Sprintf("changes to domain %s are not allowed for customer %s", e.DomainName, e.CustomerNumber)
+}
+
+// CustomerNumber returns the customer number of the account the client logs in to.
+func (c *CCPClient) CustomerNumber() string {
+	return c.loginData.CustomerNumber
+}
+
+// Endpoint returns the URL of the CCP API endpoint the client sends requests to.
+func (c *CCPClient) Endpoint() string {
+	return c.hostURL
+}
+
+// AllowedDomains returns the domains changes are restricted to, or nil if all domains are allowed.
+func (c *CCPClient) AllowedDomains() []string {
+	return c.allowedDomains
+}
+
+// ForbiddenDomains returns the domains changes are refused for.
+func (c *CCPClient) ForbiddenDomains() []string {
+	return c.forbiddenDomains
+}
+
+// IsDomainAllowed reports whether changes to a domain are allowed by the allowed and forbidden domains of the
+// client. A domain matches an entry if it is equal to it or one of its subdomains.
+func (c *CCPClient) IsDomainAllowed(domainName string) bool {
+	for _, forbidden := range c.forbiddenDomains {
+		if domainMatches(domainName, forbidden) {
+			return false
+		}
+	}
+	if len(c.allowedDomains) == 0 {
+		return true
+	}
+	for _, allowed := range c.allowedDomains {
+		if domainMatches(domainName, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// PollInterval returns the interval between two polls while waiting for records or requests, see WithPollInterval.
+func (c *CCPClient) PollInterval() time.Duration {
+	return c.pollInterval
+}
+
+// ListDomains returns all domains of the account.
+func (c *CCPClient) ListDomains(ctx context.Context) ([]Domain, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "listallDomains", c.authData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := listDomainsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.ResponseData, nil
+}
+
+// FindZone returns the domain of the account whose zone contains the fully qualified name fqdn, preferring the longest
+// domain, and the hostname of fqdn relative to that zone ("@" for the apex).
+func (c *CCPClient) FindZone(ctx context.Context, fqdn string) (string, string, error) {
+	zone, hostname, err := FindZone(ctx, c, fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf("customer %s: %w", c.CustomerNumber(), err)
+	}
+	return zone, hostname, nil
+}
+
+// FindZone returns the domain listed by domains whose zone contains the fully qualified name fqdn, preferring the
+// longest domain, and the hostname of fqdn relative to that zone ("@" for the apex).
+func FindZone(ctx context.Context, domains DomainService, fqdn string) (string, string, error) {
+	listed, err := domains.ListDomains(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(fqdn, "."))
+	zone := ""
+	for _, domain := range listed {
+		if domainMatches(name, domain.Name) && len(domain.Name) > len(zone) {
+			zone = strings.ToLower(strings.TrimSuffix(domain.Name, "."))
+		}
+	}
+	if zone == "" {
+		return "", "", fmt.Errorf("no domain of the account contains %s", fqdn)
+	}
+
+	if name == zone {
+		return zone, "@", nil
+	}
+	return zone, strings.TrimSuffix(name, "."+zone), nil
+}
+
+// ensureSession logs in unless the client already has a session. It is safe for concurrent use.
+func (c *CCPClient) ensureSession(ctx context.Context) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.authData.SessionId != "" {
+		return nil
+	}
+	return c.login(ctx)
+}
+
+// authorizeChange refuses changes to domains that are not allowed and ensures the client has a session otherwise.
+func (c *CCPClient) authorizeChange(ctx context.Context, domainName string) error {
+	if !c.IsDomainAllowed(domainName) {
+		return &DomainNotAllowedError{DomainName: domainName, CustomerNumber: c.CustomerNumber()}
+	}
+	return c.ensureSession(ctx)
+}
+
+func domainMatches(domainName string, pattern string) bool {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	return domainName == pattern || strings.HasSuffix(domainName, "."+pattern)
+}
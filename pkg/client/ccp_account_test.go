@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCCPClient_LazyLogin(t *testing.T) {
+	Convey("Given a fake CCP API counting requests", t, func() {
+		fake := ccpfake.New(customerNumber, apiKey, apiPassword)
+		fake.AddDomain(ccpfake.Domain{Name: "domain.com"})
+		var requests int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			fake.ServeHTTP(w, r)
+		}))
+		defer srv.Close()
+
+		Convey("a lazy client logs in on its first request only", func() {
+			client, err := NewCCPClient(customerNumber, apiKey, apiPassword, WithEndpoint(srv.URL), WithLazyLogin())
+			So(err, ShouldBeNil)
+			So(atomic.LoadInt32(&requests), ShouldEqual, 0)
+
+			domains, err := client.ListDomains(context.Background())
+			So(err, ShouldBeNil)
+			So(domains, ShouldHaveLength, 1)
+			So(domains[0].Name, ShouldEqual, "domain.com")
+			So(atomic.LoadInt32(&requests), ShouldEqual, 2)
+
+			_, err = client.GetDnsZone(context.Background(), "domain.com")
+			So(err, ShouldBeNil)
+			So(atomic.LoadInt32(&requests), ShouldEqual, 3)
+		})
+
+		Convey("a lazy client with wrong credentials fails on its first request", func() {
+			client, err := NewCCPClient(customerNumber, apiKey, "WRONG", WithEndpoint(srv.URL), WithLazyLogin())
+			So(err, ShouldBeNil)
+
+			_, err = client.GetDnsZone(context.Background(), "domain.com")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCCPClient_DomainRestrictions(t *testing.T) {
+	Convey("Given a client restricted to some domains", t, func() {
+		fake := ccpfake.New(customerNumber, apiKey, apiPassword)
+		fake.AddZone("domain.com")
+		fake.AddZone("other.com")
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		client, err := NewCCPClient(customerNumber, apiKey, apiPassword, WithEndpoint(srv.URL),
+			WithAllowedDomains("domain.com", "other.com"), WithForbiddenDomains("Other.com."))
+		So(err, ShouldBeNil)
+
+		Convey("changes to allowed domains are sent", func() {
+			_, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+			So(fake.Records("domain.com"), ShouldHaveLength, 1)
+		})
+
+		Convey("changes to forbidden domains are refused before sending a request", func() {
+			_, err := client.CreateDnsRecord(context.Background(), "other.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldHaveSameTypeAs, &DomainNotAllowedError{})
+			So(fake.Records("other.com"), ShouldBeEmpty)
+		})
+
+		Convey("changes to other domains are refused", func() {
+			_, err := client.CancelDomain(context.Background(), "unrelated.com")
+			So(err, ShouldHaveSameTypeAs, &DomainNotAllowedError{})
+		})
+
+		Convey("reads are not restricted", func() {
+			_, err := client.GetDnsRecords(context.Background(), "other.com")
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("subdomains match their parent domain", t, func() {
+		client := &CCPClient{allowedDomains: []string{"example.com"}}
+
+		So(client.IsDomainAllowed("sub.example.com"), ShouldBeTrue)
+		So(client.IsDomainAllowed("EXAMPLE.COM"), ShouldBeTrue)
+		So(client.IsDomainAllowed("notexample.com"), ShouldBeFalse)
+	})
+}
+
+func TestCCPClient_FindZone(t *testing.T) {
+	Convey("Given a client for an account with nested domains", t, func() {
+		fake := ccpfake.New(customerNumber, apiKey, apiPassword)
+		fake.AddDomain(ccpfake.Domain{Name: "domain.com"})
+		fake.AddDomain(ccpfake.Domain{Name: "sub.domain.com"})
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		client, err := NewCCPClient(customerNumber, apiKey, apiPassword, WithEndpoint(srv.URL))
+		So(err, ShouldBeNil)
+
+		Convey("the longest matching domain is the zone", func() {
+			zone, hostname, err := client.FindZone(context.Background(), "_acme-challenge.www.Sub.Domain.com.")
+			So(err, ShouldBeNil)
+			So(zone, ShouldEqual, "sub.domain.com")
+			So(hostname, ShouldEqual, "_acme-challenge.www")
+
+			zone, hostname, err = client.FindZone(context.Background(), "_acme-challenge.domain.com")
+			So(err, ShouldBeNil)
+			So(zone, ShouldEqual, "domain.com")
+			So(hostname, ShouldEqual, "_acme-challenge")
+		})
+
+		Convey("the apex is returned as @", func() {
+			zone, hostname, err := client.FindZone(context.Background(), "domain.com")
+			So(err, ShouldBeNil)
+			So(zone, ShouldEqual, "domain.com")
+			So(hostname, ShouldEqual, "@")
+		})
+
+		Convey("names outside of all domains are an error", func() {
+			_, _, err := client.FindZone(context.Background(), "_acme-challenge.otherdomain.com")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
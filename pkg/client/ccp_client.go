@@ -0,0 +1,779 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostURL is the default endpoint of the CCP API.
+const HostURL string = "https://ccp.netcup.net/run/webservice/servers/endpoint.php?JSON"
+
+// DefaultTimeout is the default timeout of a single request to the CCP API.
+const DefaultTimeout = 10 * time.Second
+
+type (
+	CCPClient struct {
+		hostURL          string
+		httpClient       *http.Client
+		loginData        loginData
+		lazyLogin        bool
+		sessionMu        sync.Mutex
+		authData         authData
+		allowedDomains   []string
+		forbiddenDomains []string
+		pollInterval     time.Duration
+		logger           *log.Logger
+		UserAgent        string
+	}
+
+	authData struct {
+		CustomerNumber string `json:"customernumber"`
+		APIKey         string `json:"apikey"`
+		SessionId      string `json:"apisessionid"`
+	}
+
+	loginData struct {
+		CustomerNumber string `json:"customernumber"`
+		APIKey         string `json:"apikey"`
+		APIPassword    string `json:"apipassword"`
+	}
+
+	requestBody struct {
+		Action string      `json:"action"`
+		Param  interface{} `json:"param"`
+	}
+
+	ResponseBody struct {
+		ServerRequestId string `json:"serverrequestid"`
+		ClientRequestId string `json:"clientrequestid,omitempty"`
+		Action          string `json:"action"`
+		Status          string `json:"status"`     // Status of the Message like "error", "started", "pending", "warning" or "success".
+		StatusCode      int    `json:"statuscode"` // Status code of the Message like 2011.
+		ShortMessage    string `json:"shortmessage"`
+		LongMessage     string `json:"longmessage"`
+	}
+
+	sessionData struct {
+		SessionId string `json:"apisessionid"`
+	}
+
+	loginResponse struct {
+		ResponseBody
+		ResponseData sessionData `json:"responsedata"`
+	}
+
+	DnsZone struct {
+		Name         string `json:"domainname"`
+		TTL          string `json:"ttl"`
+		Serial       string `json:"serial"`
+		Refresh      string `json:"refresh"`
+		Retry        string `json:"retry"`
+		Expire       string `json:"expire"`
+		DNSSecStatus bool   `json:"dnssecstatus"`
+	}
+
+	domainInfoRequest struct {
+		authData
+		DomainName string `json:"domainname"`
+	}
+
+	dnsZoneResponse struct {
+		ResponseBody
+		ResponseData DnsZone `json:"responsedata"`
+	}
+
+	DnsRecord struct {
+		Id           string `json:"id,omitempty"`
+		Hostname     string `json:"hostname"`
+		Type         string `json:"type"`
+		Priority     string `json:"priority,omitempty"`
+		Destination  string `json:"destination"`
+		DeleteRecord bool   `json:"deleterecord,omitempty"`
+		State        string `json:"state,omitempty"`
+	}
+
+	NewDnsRecord struct {
+		Hostname    string `json:"hostname"`
+		Type        string `json:"type"`
+		Priority    string `json:"priority,omitempty"`
+		Destination string `json:"destination"`
+	}
+
+	dnsRecordSet struct {
+		DnsRecords []DnsRecord `json:"dnsrecords,omitempty"`
+	}
+
+	dnsRecordsResponse struct {
+		ResponseBody
+		ResponseData dnsRecordSet `json:"responsedata"`
+	}
+
+	newDnsRecordSet struct {
+		DnsRecords []NewDnsRecord `json:"dnsrecords"`
+	}
+
+	createDnsRecordsRequest struct {
+		domainInfoRequest
+		DnsRecordSet newDnsRecordSet `json:"dnsrecordset"`
+	}
+
+	updateDnsRecordsRequest struct {
+		domainInfoRequest
+		DnsRecordSet dnsRecordSet `json:"dnsrecordset"`
+	}
+
+	updateDnsZoneRequest struct {
+		domainInfoRequest
+		DnsZone DnsZone `json:"dnszone"`
+	}
+)
+
+// DnsRecordNotFoundError is returned if a DNS record does not exist (anymore).
+type DnsRecordNotFoundError struct {
+	DomainName string
+	Id         string
+}
+
+func (e *DnsRecordNotFoundError) Error() string {
+	return fmt.Sprintf("could not find DNS record with ID %s for domain %s", e.Id, e.DomainName)
+}
+
+// DomainNotFoundError is returned if a domain does not exist (anymore) or does not belong to the account. Err is the
+// error of the CCP API.
+type DomainNotFoundError struct {
+	DomainName string
+	Err        error
+}
+
+func (e *DomainNotFoundError) Error() string {
+	return fmt.Sprintf("could not find domain %s: %s", e.DomainName, e.Err)
+}
+
+func (e *DomainNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// DnsZoneNotFoundError is returned if the zone of a domain does not exist (anymore), e.g. because the domain does not
+// belong to the account. Err is the error of the CCP API.
+type DnsZoneNotFoundError struct {
+	DomainName string
+	Err        error
+}
+
+func (e *DnsZoneNotFoundError) Error() string {
+	return fmt.Sprintf("could not find DNS zone of domain %s: %s", e.DomainName, e.Err)
+}
+
+func (e *DnsZoneNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// statusCodeDomainNotFound is the status code of the CCP API for requests for a domain that does not exist or does
+// not belong to the account.
+const statusCodeDomainNotFound = 5029
+
+// isDomainNotFound reports whether err is an *APIError for a domain that does not exist.
+func isDomainNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCodeDomainNotFound
+}
+
+// AmbiguousDnsRecordError is returned by CreateDnsRecord if the record was created but several new records of the
+// zone match it, e.g. because identical records were created concurrently. Ids holds the IDs of all candidates.
+type AmbiguousDnsRecordError struct {
+	DomainName string
+	Record     NewDnsRecord
+	Ids        []string
+}
+
+func (e *AmbiguousDnsRecordError) Error() string {
+	return fmt.Sprintf("could not identify newly created DNS record %s %s %s for domain %s, candidates are the records with IDs %s",
+		e.Record.Hostname, e.Record.Type, e.Record.Destination, e.DomainName, strings.Join(e.Ids, ", "))
+}
+
+// APIError is returned if the CCP API answers a request with the status "error".
+type APIError struct {
+	Action          string
+	StatusCode      int
+	ShortMessage    string
+	LongMessage     string
+	ServerRequestId string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s failed with status code %d: %s %s", e.Action, e.StatusCode, e.ShortMessage, e.LongMessage)
+}
+
+// HTTPError is returned if the CCP API endpoint answers with an HTTP status other than 200. Body is the raw response
+// body and may contain session IDs, its message is redacted like the logged bodies, see redactBody.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+// maxHTTPErrorBodyLength is the number of bytes of the redacted body included in the message of an HTTPError.
+const maxHTTPErrorBodyLength = 512
+
+func (e *HTTPError) Error() string {
+	body := redactBody([]byte(e.Body))
+	if len(body) > maxHTTPErrorBodyLength {
+		body = body[:maxHTTPErrorBodyLength] + "..."
+	}
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, body)
+}
+
+// Option configures a CCPClient created with NewCCPClient.
+type Option func(*CCPClient)
+
+// WithEndpoint sets the URL of the CCP API endpoint, e.g. to use a proxy or a fake API. Defaults to HostURL.
+func WithEndpoint(endpoint string) Option {
+	return func(c *CCPClient) {
+		c.hostURL = endpoint
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, e.g. to use a custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *CCPClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout of a single request. Defaults to DefaultTimeout. Options are applied in order, so
+// WithTimeout must follow WithHTTPClient to take effect. It modifies a copy of the HTTP client, the client passed
+// to WithHTTPClient is left unchanged.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *CCPClient) {
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+	}
+}
+
+// WithLazyLogin defers the login until the first request that needs a session, so that creating a client for an
+// account that is never used sends no requests.
+func WithLazyLogin() Option {
+	return func(c *CCPClient) {
+		c.lazyLogin = true
+	}
+}
+
+// WithAllowedDomains restricts all changes to the given domains and their subdomains. Changes to other domains are
+// refused with a DomainNotAllowedError before any request is sent. By default, all domains are allowed.
+func WithAllowedDomains(domainNames ...string) Option {
+	return func(c *CCPClient) {
+		c.allowedDomains = domainNames
+	}
+}
+
+// WithForbiddenDomains refuses all changes to the given domains and their subdomains with a DomainNotAllowedError,
+// even if they are allowed by WithAllowedDomains.
+func WithForbiddenDomains(domainNames ...string) Option {
+	return func(c *CCPClient) {
+		c.forbiddenDomains = domainNames
+	}
+}
+
+// WithPollInterval sets the interval between requests while waiting for records to become active or for pending
+// domain actions. Defaults to DefaultPollInterval.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *CCPClient) {
+		c.pollInterval = interval
+	}
+}
+
+// WithLogger sets the logger that every request and response is logged to, see logResponse. By default, nothing is
+// logged.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *CCPClient) {
+		c.logger = logger
+	}
+}
+
+// NewCCPClient creates a client and logs in to the CCP API with the given credentials, unless WithLazyLogin is given.
+// Clients with lazy login create their session with the context of the first request.
+func NewCCPClient(customerNumber, apiKey, apiPassword string, opts ...Option) (*CCPClient, error) {
+	c := CCPClient{
+		hostURL:    HostURL,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		loginData: loginData{
+			CustomerNumber: customerNumber,
+			APIKey:         apiKey,
+			APIPassword:    apiPassword,
+		},
+		pollInterval: DefaultPollInterval,
+		logger:       log.New(ioutil.Discard, "", 0),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.lazyLogin {
+		return &c, nil
+	}
+
+	err := c.ensureSession(context.Background())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (c *CCPClient) login(ctx context.Context) error {
+	body, err := c.doRequest(ctx, "login", c.loginData)
+	if err != nil {
+		return err
+	}
+
+	res := loginResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return err
+	}
+
+	c.authData = authData{
+		CustomerNumber: c.loginData.CustomerNumber,
+		APIKey:         c.loginData.APIKey,
+		SessionId:      res.ResponseData.SessionId,
+	}
+	return nil
+}
+
+func (c *CCPClient) doRequest(ctx context.Context, action string, param interface{}) ([]byte, error) {
+	rb, err := json.Marshal(requestBody{
+		Action: action,
+		Param:  param,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.hostURL, strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logResponse(action, time.Since(start), 0, rb, nil, err)
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	defer func() { c.logResponse(action, time.Since(start), res.StatusCode, rb, body, err) }()
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		err = &HTTPError{StatusCode: res.StatusCode, Body: string(body)}
+		return nil, err
+	}
+
+	status := ResponseBody{}
+	if err = json.Unmarshal(body, &status); err != nil {
+		return nil, err
+	}
+	if err = status.Err(); err != nil {
+		return nil, err
+	}
+
+	return body, err
+}
+
+func parseResponseBody(body []byte) (*ResponseBody, error) {
+	res := ResponseBody{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Err returns an *APIError describing the failed API call if the response status is "error".
+func (r ResponseBody) Err() error {
+	if r.Status != "error" {
+		return nil
+	}
+	return &APIError{
+		Action:          r.Action,
+		StatusCode:      r.StatusCode,
+		ShortMessage:    r.ShortMessage,
+		LongMessage:     r.LongMessage,
+		ServerRequestId: r.ServerRequestId,
+	}
+}
+
+func (c *CCPClient) GetDnsZone(ctx context.Context, domainName string) (*DnsZone, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "infoDnsZone", domainInfoRequest{
+		authData:   c.authData,
+		DomainName: domainName,
+	})
+
+	if isDomainNotFound(err) {
+		return nil, &DnsZoneNotFoundError{DomainName: domainName, Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsZoneResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.ResponseData, nil
+}
+
+// UpdateDnsZone changes the TTL, SOA intervals and DNSSEC status of a zone and returns the updated zone settings.
+// The serial is set by netcup.
+func (c *CCPClient) UpdateDnsZone(ctx context.Context, domainName string, zone DnsZone) (*DnsZone, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	zone.Name = domainName
+	body, err := c.doRequest(ctx, "updateDnsZone", updateDnsZoneRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		DnsZone: zone,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsZoneResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.ResponseData, nil
+}
+
+func (c *CCPClient) GetDnsRecords(ctx context.Context, domainName string) ([]DnsRecord, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "infoDnsRecords", domainInfoRequest{
+		authData:   c.authData,
+		DomainName: domainName,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsRecordsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ResponseData.DnsRecords, nil
+}
+
+func (c *CCPClient) GetDnsRecordById(ctx context.Context, domainName string, id string) (*DnsRecord, error) {
+	records, err := c.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Id == id {
+			return &record, nil
+		}
+	}
+	return nil, &DnsRecordNotFoundError{DomainName: domainName, Id: id}
+}
+
+// CreateDnsRecord creates a record and returns it with the ID assigned by netcup. The new record is identified by
+// its ID, which did not exist before the record was created, so existing identical records are never mistaken for it.
+func (c *CCPClient) CreateDnsRecord(ctx context.Context, domainName string, record NewDnsRecord) (*DnsRecord, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	existing, err := c.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	existingIds := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		existingIds[r.Id] = true
+	}
+
+	body, err := c.doRequest(ctx, "updateDnsRecords", createDnsRecordsRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		DnsRecordSet: newDnsRecordSet{DnsRecords: []NewDnsRecord{record}},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsRecordsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return findNewRecord(domainName, res.ResponseData.DnsRecords, existingIds, record)
+}
+
+func (c *CCPClient) UpdateDnsRecord(ctx context.Context, domainName string, record DnsRecord) (*DnsRecord, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "updateDnsRecords", updateDnsRecordsRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		DnsRecordSet: dnsRecordSet{DnsRecords: []DnsRecord{record}},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsRecordsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	newRecord, err := findRecordById(res.ResponseData.DnsRecords, record.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRecord, nil
+}
+
+func (c *CCPClient) DeleteDnsRecord(ctx context.Context, domainName string, record DnsRecord) error {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return err
+	}
+	deleteRecord := record
+	deleteRecord.DeleteRecord = true
+	body, err := c.doRequest(ctx, "updateDnsRecords", updateDnsRecordsRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		DnsRecordSet: dnsRecordSet{DnsRecords: []DnsRecord{deleteRecord}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	res := dnsRecordsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return err
+	}
+
+	_, err = findRecordById(res.ResponseData.DnsRecords, record.Id)
+	if err == nil {
+		// we expect the record to be gone from the response
+		return fmt.Errorf("failed to delete DNS record with ID %s", record.Id)
+	}
+	return nil
+}
+
+// UpdateDnsRecords applies a set of changes to a zone in a single request and returns all records of the zone
+// afterwards. Records without ID are created, records with DeleteRecord set are deleted and all other records are
+// updated. The API applies either all changes or none.
+func (c *CCPClient) UpdateDnsRecords(ctx context.Context, domainName string, records []DnsRecord) ([]DnsRecord, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "updateDnsRecords", updateDnsRecordsRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		DnsRecordSet: dnsRecordSet{DnsRecords: records},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := dnsRecordsResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ResponseData.DnsRecords, nil
+}
+
+func findRecordById(records []DnsRecord, id string) (*DnsRecord, error) {
+	for _, record := range records {
+		if record.Id == id {
+			return &record, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find DNS record with ID %s", id)
+}
+
+// findNewRecord identifies the created record among the records of a zone after creating requestedRecord. Records
+// whose IDs are in existingIds existed before. If several records are new, e.g. because other records were created
+// concurrently, the one matching requestedRecord is chosen, allowing for the API to normalise its values.
+func findNewRecord(domainName string, records []DnsRecord, existingIds map[string]bool, requestedRecord NewDnsRecord) (*DnsRecord, error) {
+	var candidates []DnsRecord
+	for _, record := range records {
+		if !existingIds[record.Id] {
+			candidates = append(candidates, record)
+		}
+	}
+	if len(candidates) == 1 {
+		return &candidates[0], nil
+	}
+
+	var matches []DnsRecord
+	for _, record := range candidates {
+		if requestedRecord.Matches(record) {
+			matches = append(matches, record)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("could not retrieve newly created DNS record %s %s %s for domain %s, no new record was returned",
+				requestedRecord.Hostname, requestedRecord.Type, requestedRecord.Destination, domainName)
+		}
+		return nil, &AmbiguousDnsRecordError{DomainName: domainName, Record: requestedRecord, Ids: recordIds(candidates)}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &AmbiguousDnsRecordError{DomainName: domainName, Record: requestedRecord, Ids: recordIds(matches)}
+	}
+}
+
+func normalizeHostname(hostname string) string {
+	if hostname == "" {
+		return "@"
+	}
+	return strings.TrimSuffix(hostname, ".")
+}
+
+func normalizeDestination(recordType string, destination string) string {
+	destination = strings.TrimSpace(destination)
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		if ip := net.ParseIP(destination); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "MX", "NS", "SRV":
+		return strings.ToLower(strings.TrimSuffix(destination, "."))
+	case "TXT":
+		if len(destination) >= 2 && strings.HasPrefix(destination, `"`) && strings.HasSuffix(destination, `"`) {
+			return destination[1 : len(destination)-1]
+		}
+	}
+	return destination
+}
+
+func recordIds(records []DnsRecord) []string {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.Id
+	}
+	return ids
+}
+
+// Matches reports whether r2 is the record r describes, with the same normalization as DnsRecord.Matches.
+func (r NewDnsRecord) Matches(r2 DnsRecord) bool {
+	return DnsRecord{Hostname: r.Hostname, Type: r.Type, Priority: r.Priority, Destination: r.Destination}.Matches(r2)
+}
+
+// Matches reports whether two records have the same hostname, type, destination and, for MX and SRV records,
+// priority, after normalizing both the way the CCP API may: hostnames, types and domain names are compared
+// case-insensitively and without trailing dots, addresses by their value and TXT values without quotes. A
+// destination exported with a trailing dot thereby matches the same destination stored without. IDs and states are
+// not compared.
+func (r DnsRecord) Matches(r2 DnsRecord) bool {
+	if !strings.EqualFold(normalizeHostname(r.Hostname), normalizeHostname(r2.Hostname)) || !strings.EqualFold(r.Type, r2.Type) {
+		return false
+	}
+	if normalizeDestination(r.Type, r.Destination) != normalizeDestination(r2.Type, r2.Destination) {
+		return false
+	}
+	switch strings.ToUpper(r.Type) {
+	case "MX", "SRV":
+		return priorityOrZero(r.Priority) == priorityOrZero(r2.Priority)
+	}
+	return true
+}
+
+// DnsRecordChanges returns the changes for UpdateDnsRecords that create the desired records missing from existing
+// and delete the existing records that match no desired record and for which remove returns true.
+func DnsRecordChanges(existing []DnsRecord, desired []DnsRecord, remove func(DnsRecord) bool) []DnsRecord {
+	kept := map[string]bool{}
+	var changes []DnsRecord
+	for _, record := range desired {
+		if e := findUnusedRecord(existing, record, kept); e != nil {
+			kept[e.Id] = true
+			continue
+		}
+		changes = append(changes, record)
+	}
+	for _, e := range existing {
+		if !kept[e.Id] && remove(e) {
+			e.DeleteRecord = true
+			changes = append(changes, e)
+		}
+	}
+	return changes
+}
+
+// FindDnsRecords returns a distinct matching record of records for each desired record, in the order of desired.
+func FindDnsRecords(records []DnsRecord, desired []DnsRecord) ([]DnsRecord, error) {
+	used := map[string]bool{}
+	found := make([]DnsRecord, 0, len(desired))
+	for _, record := range desired {
+		r := findUnusedRecord(records, record, used)
+		if r == nil {
+			return nil, fmt.Errorf("could not find DNS record %s %s %s", record.Hostname, record.Type, record.Destination)
+		}
+		used[r.Id] = true
+		found = append(found, *r)
+	}
+	return found, nil
+}
+
+// findUnusedRecord returns the first record matching record whose ID is not in used.
+func findUnusedRecord(records []DnsRecord, record DnsRecord, used map[string]bool) *DnsRecord {
+	for i := range records {
+		if !used[records[i].Id] && records[i].Matches(record) {
+			return &records[i]
+		}
+	}
+	return nil
+}
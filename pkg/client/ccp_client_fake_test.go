@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	. "github.com/smartystreets/goconvey/convey"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// setupFakeClientTest returns a client logged into a fake CCP API that serves the zone "domain.com".
+func setupFakeClientTest() (*CCPClient, *ccpfake.Server, func()) {
+	fake := ccpfake.New(customerNumber, apiKey, apiPassword)
+	fake.AddZone("domain.com")
+	srv := httptest.NewServer(fake)
+
+	client, err := NewCCPClient(customerNumber, apiKey, apiPassword, WithEndpoint(srv.URL))
+	if err != nil {
+		panic(err)
+	}
+	client.pollInterval = time.Millisecond
+
+	return client, fake, srv.Close
+}
+
+func TestCCPClient_DnsRecordLifecycle(t *testing.T) {
+	Convey("Given a client for a fake CCP API", t, func() {
+		client, fake, tearDown := setupFakeClientTest()
+		defer tearDown()
+
+		Convey("a record can be created, updated and deleted", func() {
+			created, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+			So(created.Id, ShouldNotBeEmpty)
+
+			created.Destination = "5.6.7.8"
+			updated, err := client.UpdateDnsRecord(context.Background(), "domain.com", *created)
+			So(err, ShouldBeNil)
+			So(updated.Id, ShouldEqual, created.Id)
+			So(updated.Destination, ShouldEqual, "5.6.7.8")
+
+			So(client.DeleteDnsRecord(context.Background(), "domain.com", *updated), ShouldBeNil)
+			So(fake.Records("domain.com"), ShouldBeEmpty)
+		})
+
+		Convey("a record identical to an existing one is created with its own ID", func() {
+			first, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+
+			second, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+			So(second.Id, ShouldNotEqual, first.Id)
+			So(fake.Records("domain.com"), ShouldHaveLength, 2)
+		})
+
+		Convey("API errors are returned", func() {
+			_, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "FOO", Destination: "bar"})
+			So(err, ShouldNotBeNil)
+
+			_, err = client.GetDnsZone(context.Background(), "other.com")
+			var apiErr *APIError
+			So(errors.As(err, &apiErr), ShouldBeTrue)
+			So(apiErr.Action, ShouldEqual, "infoDnsZone")
+			So(apiErr.ServerRequestId, ShouldNotBeEmpty)
+		})
+
+		Convey("requests are canceled with their context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := client.GetDnsZone(ctx, "domain.com")
+			So(errors.Is(err, context.Canceled), ShouldBeTrue)
+		})
+
+		Convey("the zone settings can be updated", func() {
+			zone, err := client.GetDnsZone(context.Background(), "domain.com")
+			So(err, ShouldBeNil)
+
+			zone.TTL = "3600"
+			zone.DNSSecStatus = true
+			updated, err := client.UpdateDnsZone(context.Background(), "domain.com", *zone)
+			So(err, ShouldBeNil)
+			So(updated.TTL, ShouldEqual, "3600")
+			So(updated.Refresh, ShouldEqual, zone.Refresh)
+			So(updated.DNSSecStatus, ShouldBeTrue)
+			So(updated.Serial, ShouldNotEqual, zone.Serial)
+
+			zone.TTL = "0"
+			_, err = client.UpdateDnsZone(context.Background(), "domain.com", *zone)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("the zone serial is bumped by record changes", func() {
+			before, err := client.GetDnsZone(context.Background(), "domain.com")
+			So(err, ShouldBeNil)
+
+			_, err = client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+
+			after, err := client.GetDnsZone(context.Background(), "domain.com")
+			So(err, ShouldBeNil)
+			So(after.Serial, ShouldNotEqual, before.Serial)
+		})
+
+		Convey("waiting for activation polls until the record is active", func() {
+			fake.ActivateAfter = 2
+			created, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+			So(err, ShouldBeNil)
+			So(created.State, ShouldEqual, "unknown")
+
+			active, err := client.WaitForDnsRecordActive(context.Background(), "domain.com", created.Id)
+			So(err, ShouldBeNil)
+			So(active.State, ShouldEqual, RecordStateActive)
+		})
+
+		Convey("a domain transfer is awaited via the message queue", func() {
+			res, err := client.TransferDomain(context.Background(), "transfer.de", "AUTH_CODE", DomainContacts{OwnerC: "1", AdminC: "2", TechC: "3"}, nil)
+			So(err, ShouldBeNil)
+			So(res.IsPending(), ShouldBeTrue)
+
+			So(client.Await(context.Background(), res), ShouldBeNil)
+			So(fake.Messages(), ShouldBeEmpty)
+
+			domain, err := client.GetDomain(context.Background(), "transfer.de")
+			So(err, ShouldBeNil)
+			So(domain.Contacts.TechC, ShouldEqual, "3")
+		})
+	})
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/h2non/gock.v1"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	customerNumber = "CUSTOMER_NUMBER"
+	apiKey         = "API_KEY"
+	apiPassword    = "API_PASSWORD"
+)
+
+func setupClientTest(opts ...Option) (*CCPClient, func()) {
+	gock.New(HostURL).Post("").BodyString(`{"action":"login","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apipassword":"API_PASSWORD"}}`).
+		Reply(200).Type("application/json").
+		BodyString(`{"responsedata":{"apisessionid":"SESSION_ID"}}`)
+
+	client, err := NewCCPClient(customerNumber, apiKey, apiPassword, opts...)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return client, gock.Off
+}
+
+func TestNewCCPClient(t *testing.T) {
+	Convey("sends a login request upon creation", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		So(client.authData.SessionId, ShouldEqual, "SESSION_ID")
+	})
+}
+
+func TestCCPClient_GetDnsZone(t *testing.T) {
+	Convey("retrieves the DNS zone data for a given domain name", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"infoDnsZone","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"domainname":"domain.com","ttl":"86400","serial":"1234","refresh":"28800","retry":"7200","expire":"1209600","dnssecstatus":true}}`)
+
+		dnsZone, err := client.GetDnsZone(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(*dnsZone, ShouldResemble, DnsZone{
+			Name:         "domain.com",
+			TTL:          "86400",
+			Serial:       "1234",
+			Refresh:      "28800",
+			Retry:        "7200",
+			Expire:       "1209600",
+			DNSSecStatus: true,
+		})
+	})
+}
+
+func TestCCPClient_GetDnsZone_notFound(t *testing.T) {
+	Convey("returns a DnsZoneNotFoundError for a domain without zone", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			Reply(200).Type("application/json").
+			BodyString(`{"action":"infoDnsZone","status":"error","statuscode":5029,"shortmessage":"Can not get DNS records for zone. Domain not found."}`)
+
+		_, err := client.GetDnsZone(context.Background(), "domain.com")
+
+		var notFound *DnsZoneNotFoundError
+		So(errors.As(err, &notFound), ShouldBeTrue)
+		So(notFound.DomainName, ShouldEqual, "domain.com")
+	})
+}
+
+func TestCCPClient_CreateDnsRecord(t *testing.T) {
+	Convey("creates new DNS record and returns it", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"infoDnsRecords","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"dnsrecords":[{"id":"5838738","hostname":"*","type":"A","priority":"0","destination":"1.2.3.4","deleterecord":false,"state":"yes"}]}}`)
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"updateDnsRecords","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com","dnsrecordset":{"dnsrecords":[{"hostname":"HOSTNAME","type":"TXT","destination":"DESTINATION"}]}}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"dnsrecords":[{"id":"5838738","hostname":"*","type":"A","priority":"0","destination":"1.2.3.4","deleterecord":false,"state":"yes"},{"id":"5838739","hostname":"HOSTNAME","type":"TXT","priority":"0","destination":"DESTINATION","deleterecord":false,"state":"yes"}]}}`)
+
+		newRecord, err := client.CreateDnsRecord(context.Background(), "domain.com", NewDnsRecord{
+			Hostname:    "HOSTNAME",
+			Type:        "TXT",
+			Destination: "DESTINATION",
+		})
+
+		So(err, ShouldBeNil)
+		So(*newRecord, ShouldResemble, DnsRecord{
+			Id:           "5838739",
+			Hostname:     "HOSTNAME",
+			Type:         "TXT",
+			Priority:     "0",
+			Destination:  "DESTINATION",
+			DeleteRecord: false,
+			State:        "yes",
+		})
+	})
+}
+
+func TestFindNewRecord(t *testing.T) {
+	Convey("Given the records of a zone after creating a record", t, func() {
+		requested := NewDnsRecord{Hostname: "www", Type: "CNAME", Destination: "target.example.com"}
+		existingIds := map[string]bool{"1": true}
+
+		Convey("the record with a new ID is returned even if an identical record existed before", func() {
+			record, err := findNewRecord("domain.com", []DnsRecord{
+				{Id: "1", Hostname: "www", Type: "CNAME", Destination: "target.example.com"},
+				{Id: "2", Hostname: "www", Type: "CNAME", Destination: "target.example.com"},
+			}, existingIds, requested)
+
+			So(err, ShouldBeNil)
+			So(record.Id, ShouldEqual, "2")
+		})
+
+		Convey("a single new record is returned even if the API normalised its value", func() {
+			record, err := findNewRecord("domain.com", []DnsRecord{
+				{Id: "1", Hostname: "mail", Type: "A", Destination: "1.2.3.4"},
+				{Id: "2", Hostname: "www", Type: "CNAME", Destination: "target.example.com."},
+			}, existingIds, requested)
+
+			So(err, ShouldBeNil)
+			So(record.Id, ShouldEqual, "2")
+		})
+
+		Convey("among several new records the normalised match is returned", func() {
+			record, err := findNewRecord("domain.com", []DnsRecord{
+				{Id: "2", Hostname: "mail", Type: "A", Destination: "1.2.3.4"},
+				{Id: "3", Hostname: "WWW", Type: "cname", Destination: "Target.Example.com."},
+			}, existingIds, requested)
+
+			So(err, ShouldBeNil)
+			So(record.Id, ShouldEqual, "3")
+		})
+
+		Convey("several matching new records are ambiguous", func() {
+			_, err := findNewRecord("domain.com", []DnsRecord{
+				{Id: "2", Hostname: "www", Type: "CNAME", Destination: "target.example.com"},
+				{Id: "3", Hostname: "www", Type: "CNAME", Destination: "target.example.com"},
+			}, existingIds, requested)
+
+			var ambiguous *AmbiguousDnsRecordError
+			So(errors.As(err, &ambiguous), ShouldBeTrue)
+			So(ambiguous.Ids, ShouldResemble, []string{"2", "3"})
+		})
+
+		Convey("no new record is an error", func() {
+			_, err := findNewRecord("domain.com", []DnsRecord{
+				{Id: "1", Hostname: "www", Type: "CNAME", Destination: "target.example.com"},
+			}, existingIds, requested)
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestDnsRecord_Matches(t *testing.T) {
+	Convey("records match after normalization", t, func() {
+		record := DnsRecord{Id: "1", Hostname: "www", Type: "cname", Priority: "0", Destination: "Target.Example.com."}
+
+		So(record.Matches(DnsRecord{Hostname: "www.", Type: "CNAME", Destination: "target.example.com"}), ShouldBeTrue)
+		So(record.Matches(DnsRecord{Hostname: "www", Type: "CNAME", Destination: "other.example.com"}), ShouldBeFalse)
+		So(DnsRecord{Hostname: "@", Type: "MX", Destination: "mail"}.Matches(DnsRecord{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail"}), ShouldBeFalse)
+	})
+
+	Convey("new records match like the records they describe", t, func() {
+		record := DnsRecord{Id: "1", Hostname: "www", Type: "A", Priority: "0", Destination: "1.2.3.4"}
+
+		So(NewDnsRecord{Hostname: "www", Type: "a", Destination: "1.2.3.4"}.Matches(record), ShouldBeTrue)
+		So(NewDnsRecord{Hostname: "www", Type: "A", Destination: "1.2.3.5"}.Matches(record), ShouldBeFalse)
+	})
+}
+
+func TestNewCCPClient_Options(t *testing.T) {
+	Convey("sends requests to the configured endpoint with the configured HTTP client", t, func() {
+		defer gock.Off()
+		httpClient := &http.Client{}
+		gock.InterceptClient(httpClient)
+
+		gock.New("https://proxy.example.com").Post("/ccp").
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"apisessionid":"SESSION_ID"}}`)
+
+		client, err := NewCCPClient(customerNumber, apiKey, apiPassword,
+			WithHTTPClient(httpClient), WithEndpoint("https://proxy.example.com/ccp"), WithTimeout(time.Minute))
+
+		So(err, ShouldBeNil)
+		So(client.authData.SessionId, ShouldEqual, "SESSION_ID")
+		So(client.httpClient.Timeout, ShouldEqual, time.Minute)
+		So(httpClient.Timeout, ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,314 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxNameservers = 8
+
+type (
+	DomainContacts struct {
+		OwnerC         string `json:"ownerc"`
+		AdminC         string `json:"adminc"`
+		TechC          string `json:"techc"`
+		ZoneC          string `json:"zonec,omitempty"`
+		BillingC       string `json:"billingc,omitempty"`
+		OnsiteC        string `json:"onsitec,omitempty"`
+		GeneralRequest string `json:"generalrequest,omitempty"`
+		AbuseContact   string `json:"abusecontact,omitempty"`
+	}
+
+	Nameserver struct {
+		Hostname string `json:"hostname"`
+		IPv4     string `json:"ipv4,omitempty"`
+		IPv6     string `json:"ipv6,omitempty"`
+	}
+
+	// NameserverSet is serialized as an object with numbered keys ("nameserver1", "nameserver2", ...)
+	// as expected by the CCP API.
+	NameserverSet []Nameserver
+
+	Domain struct {
+		Name        string         `json:"domainname"`
+		Created     string         `json:"domaincreated,omitempty"`
+		Expires     string         `json:"domainexpires,omitempty"`
+		State       string         `json:"state,omitempty"`
+		Contacts    DomainContacts `json:"assignedcontacts"`
+		Nameservers NameserverSet  `json:"nameserverentry"`
+	}
+
+	domainResponse struct {
+		ResponseBody
+		ResponseData Domain `json:"responsedata"`
+	}
+
+	domainRequest struct {
+		domainInfoRequest
+		Contacts    DomainContacts `json:"contacts"`
+		Nameservers NameserverSet  `json:"nameservers"`
+	}
+
+	transferDomainRequest struct {
+		domainRequest
+		AuthCode string `json:"authcode"`
+	}
+
+	authCodeData struct {
+		AuthCode string `json:"authcode"`
+	}
+
+	authCodeResponse struct {
+		ResponseBody
+		ResponseData authCodeData `json:"responsedata"`
+	}
+
+	// Price is an amount of money in the currency of the customer account. It is decoded from JSON numbers as well
+	// as from strings with either a decimal point or a decimal comma.
+	Price float64
+
+	TopLevelDomainPrice struct {
+		TopLevelDomain string `json:"topleveldomain"`
+		PriceCreate    Price  `json:"pricecreate"`
+		PriceRenew     Price  `json:"pricerenew"`
+		PriceTransfer  Price  `json:"pricetransfer"`
+		Currency       string `json:"currency,omitempty"`
+	}
+
+	topLevelDomainPriceRequest struct {
+		authData
+		TopLevelDomain string `json:"topleveldomain"`
+	}
+
+	topLevelDomainPriceResponse struct {
+		ResponseBody
+		ResponseData TopLevelDomainPrice `json:"responsedata"`
+	}
+)
+
+func (n NameserverSet) MarshalJSON() ([]byte, error) {
+	if len(n) > maxNameservers {
+		return nil, fmt.Errorf("at most %d nameservers are supported, got %d", maxNameservers, len(n))
+	}
+	entries := make(map[string]Nameserver, len(n))
+	for i, ns := range n {
+		entries["nameserver"+strconv.Itoa(i+1)] = ns
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON accepts both a list of nameservers and the numbered object form used in requests.
+func (n *NameserverSet) UnmarshalJSON(data []byte) error {
+	var list []Nameserver
+	if err := json.Unmarshal(data, &list); err == nil {
+		*n = list
+		return nil
+	}
+
+	entries := map[string]Nameserver{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return nameserverIndex(keys[i]) < nameserverIndex(keys[j])
+	})
+
+	set := make(NameserverSet, 0, len(keys))
+	for _, key := range keys {
+		if entries[key].Hostname != "" {
+			set = append(set, entries[key])
+		}
+	}
+	*n = set
+	return nil
+}
+
+func (p *Price) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*p = Price(number)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	if text == "" {
+		*p = 0
+		return nil
+	}
+	number, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(text), ",", ".", 1), 64)
+	if err != nil {
+		return fmt.Errorf("invalid price %q: %w", text, err)
+	}
+	*p = Price(number)
+	return nil
+}
+
+func nameserverIndex(key string) int {
+	i, err := strconv.Atoi(strings.TrimPrefix(key, "nameserver"))
+	if err != nil {
+		return maxNameservers + 1
+	}
+	return i
+}
+
+// CreateDomain registers a domain. Registration may be processed asynchronously, use Await to wait for its completion.
+func (c *CCPClient) CreateDomain(ctx context.Context, domainName string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "createDomain", domainRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		Contacts:    contacts,
+		Nameservers: nameservers,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponseBody(body)
+}
+
+func (c *CCPClient) GetDomain(ctx context.Context, domainName string) (*Domain, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "infoDomain", domainInfoRequest{
+		authData:   c.authData,
+		DomainName: domainName,
+	})
+
+	if isDomainNotFound(err) {
+		return nil, &DomainNotFoundError{DomainName: domainName, Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := domainResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.ResponseData, nil
+}
+
+func (c *CCPClient) UpdateDomain(ctx context.Context, domainName string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "updateDomain", domainRequest{
+		domainInfoRequest: domainInfoRequest{
+			authData:   c.authData,
+			DomainName: domainName,
+		},
+		Contacts:    contacts,
+		Nameservers: nameservers,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponseBody(body)
+}
+
+func (c *CCPClient) CancelDomain(ctx context.Context, domainName string) (*ResponseBody, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "cancelDomain", domainInfoRequest{
+		authData:   c.authData,
+		DomainName: domainName,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponseBody(body)
+}
+
+// TransferDomain requests the transfer of a domain to netcup. The transfer is processed asynchronously, use
+// Await to wait for its completion.
+func (c *CCPClient) TransferDomain(ctx context.Context, domainName string, authCode string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error) {
+	if err := c.authorizeChange(ctx, domainName); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "transferDomain", transferDomainRequest{
+		domainRequest: domainRequest{
+			domainInfoRequest: domainInfoRequest{
+				authData:   c.authData,
+				DomainName: domainName,
+			},
+			Contacts:    contacts,
+			Nameservers: nameservers,
+		},
+		AuthCode: authCode,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponseBody(body)
+}
+
+// GetAuthCode retrieves the auth code required to transfer a domain away from netcup.
+func (c *CCPClient) GetAuthCode(ctx context.Context, domainName string) (string, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return "", err
+	}
+	body, err := c.doRequest(ctx, "getAuthcodeDomain", domainInfoRequest{
+		authData:   c.authData,
+		DomainName: domainName,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	res := authCodeResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.ResponseData.AuthCode, nil
+}
+
+// GetTopLevelDomainPrice retrieves the registration, renewal and transfer prices for a top level domain such as "de".
+func (c *CCPClient) GetTopLevelDomainPrice(ctx context.Context, topLevelDomain string) (*TopLevelDomainPrice, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "priceTopleveldomain", topLevelDomainPriceRequest{
+		authData:       c.authData,
+		TopLevelDomain: strings.TrimPrefix(topLevelDomain, "."),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := topLevelDomainPriceResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.ResponseData, nil
+}
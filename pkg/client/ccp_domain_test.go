@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/h2non/gock.v1"
+	"testing"
+)
+
+func TestCCPClient_CreateDomain(t *testing.T) {
+	Convey("sends contact handles and numbered nameservers", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"createDomain","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com","contacts":{"ownerc":"1","adminc":"2","techc":"3"},"nameservers":{"nameserver1":{"hostname":"ns1.domain.com","ipv4":"1.2.3.4"},"nameserver2":{"hostname":"ns2.other.com"}}}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"action":"createDomain","status":"success","statuscode":2000}`)
+
+		res, err := client.CreateDomain(context.Background(), "domain.com", DomainContacts{OwnerC: "1", AdminC: "2", TechC: "3"}, NameserverSet{
+			{Hostname: "ns1.domain.com", IPv4: "1.2.3.4"},
+			{Hostname: "ns2.other.com"},
+		})
+
+		So(err, ShouldBeNil)
+		So(res.IsPending(), ShouldBeFalse)
+		So(gock.IsDone(), ShouldBeTrue)
+	})
+
+	Convey("returns an error if the API reports a failure", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			Reply(200).Type("application/json").
+			BodyString(`{"action":"createDomain","status":"error","statuscode":4013,"shortmessage":"Validation Error.","longmessage":"Domain is not available."}`)
+
+		_, err := client.CreateDomain(context.Background(), "domain.com", DomainContacts{}, nil)
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Domain is not available.")
+	})
+}
+
+func TestCCPClient_GetDomain(t *testing.T) {
+	Convey("retrieves domain information including nameservers in order", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"infoDomain","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":{"domainname":"domain.com","state":"active","assignedcontacts":{"ownerc":"1","adminc":"2","techc":"3"},"nameserverentry":{"nameserver2":{"hostname":"ns2.other.com"},"nameserver1":{"hostname":"ns1.domain.com","ipv4":"1.2.3.4"}}}}`)
+
+		domain, err := client.GetDomain(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(*domain, ShouldResemble, Domain{
+			Name:     "domain.com",
+			State:    "active",
+			Contacts: DomainContacts{OwnerC: "1", AdminC: "2", TechC: "3"},
+			Nameservers: NameserverSet{
+				{Hostname: "ns1.domain.com", IPv4: "1.2.3.4"},
+				{Hostname: "ns2.other.com"},
+			},
+		})
+	})
+}
+
+func TestCCPClient_GetDomain_notFound(t *testing.T) {
+	Convey("returns a DomainNotFoundError for a domain that does not exist", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			Reply(200).Type("application/json").
+			BodyString(`{"action":"infoDomain","status":"error","statuscode":5029,"shortmessage":"Domain not found."}`)
+
+		_, err := client.GetDomain(context.Background(), "domain.com")
+
+		var notFound *DomainNotFoundError
+		So(errors.As(err, &notFound), ShouldBeTrue)
+		So(notFound.DomainName, ShouldEqual, "domain.com")
+		var apiErr *APIError
+		So(errors.As(err, &apiErr), ShouldBeTrue)
+	})
+}
+
+func TestCCPClient_GetAuthCode(t *testing.T) {
+	Convey("retrieves the auth code of a domain", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"getAuthcodeDomain","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":{"authcode":"AUTH_CODE"}}`)
+
+		authCode, err := client.GetAuthCode(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(authCode, ShouldEqual, "AUTH_CODE")
+	})
+}
+
+func TestCCPClient_GetTopLevelDomainPrice(t *testing.T) {
+	Convey("retrieves prices given as numbers or strings", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"priceTopleveldomain","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","topleveldomain":"de"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":{"topleveldomain":"de","pricecreate":"5,04","pricerenew":5.04,"pricetransfer":"0.00","currency":"EUR"}}`)
+
+		price, err := client.GetTopLevelDomainPrice(context.Background(), ".de")
+
+		So(err, ShouldBeNil)
+		So(*price, ShouldResemble, TopLevelDomainPrice{
+			TopLevelDomain: "de",
+			PriceCreate:    5.04,
+			PriceRenew:     5.04,
+			PriceTransfer:  0,
+			Currency:       "EUR",
+		})
+	})
+}
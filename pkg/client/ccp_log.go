@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// redacted replaces the values of sensitive fields in logged request and response bodies.
+const redacted = "REDACTED"
+
+// sensitiveFields are the JSON keys whose values are never logged: credentials, session IDs, auth codes and the
+// personal data of contact handles.
+var sensitiveFields = map[string]bool{
+	"apipassword":  true,
+	"apikey":       true,
+	"apisessionid": true,
+	"authcode":     true,
+	"name":         true,
+	"organisation": true,
+	"street":       true,
+	"postalcode":   true,
+	"city":         true,
+	"telephone":    true,
+	"email":        true,
+	"fax":          true,
+}
+
+// logResponse logs the outcome of an API call at DEBUG level and both bodies at TRACE level to the logger of the
+// client. It is called for every request, failed or not, so that a failed apply can be traced back to the calls that
+// caused it.
+func (c *CCPClient) logResponse(action string, duration time.Duration, httpStatus int, requestBody, responseBody []byte, err error) {
+	status := ResponseBody{}
+	_ = json.Unmarshal(responseBody, &status)
+
+	if err != nil {
+		c.logger.Printf("[DEBUG] CCP API %s failed after %s (HTTP status %d, server request ID %q, status code %d): %s",
+			action, duration, httpStatus, status.ServerRequestId, status.StatusCode, err)
+	} else {
+		c.logger.Printf("[DEBUG] CCP API %s completed in %s (HTTP status %d, server request ID %q, status %q, status code %d)",
+			action, duration, httpStatus, status.ServerRequestId, status.Status, status.StatusCode)
+	}
+
+	c.logger.Printf("[TRACE] CCP API %s request body: %s", action, redactBody(requestBody))
+	if responseBody != nil {
+		c.logger.Printf("[TRACE] CCP API %s response body: %s", action, redactBody(responseBody))
+	}
+}
+
+// redactBody returns a JSON body with the values of all sensitive fields replaced. A body that is not valid JSON
+// cannot be redacted reliably and is not returned at all.
+func redactBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON data>", len(body))
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of unprintable data>", len(body))
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if sensitiveFields[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
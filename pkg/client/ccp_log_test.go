@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func captureLog() (*bytes.Buffer, func()) {
+	buf := &bytes.Buffer{}
+	log.SetOutput(buf)
+	return buf, func() { log.SetOutput(os.Stderr) }
+}
+
+func TestCCPClient_Logging(t *testing.T) {
+	Convey("logs every request without credentials", t, func() {
+		buf := &bytes.Buffer{}
+		client, tearDown := setupClientTest(WithLogger(log.New(buf, "", 0)))
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"infoDnsZone","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","domainname":"domain.com"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"serverrequestid":"SERVER_REQUEST_ID","action":"infoDnsZone","status":"success","statuscode":2000,"responsedata":{"domainname":"domain.com"}}`)
+
+		_, err := client.GetDnsZone(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `[DEBUG] CCP API login completed in`)
+		So(buf.String(), ShouldContainSubstring, `[DEBUG] CCP API infoDnsZone completed in`)
+		So(buf.String(), ShouldContainSubstring, `server request ID "SERVER_REQUEST_ID", status "success", status code 2000`)
+		So(buf.String(), ShouldContainSubstring, `[TRACE] CCP API infoDnsZone response body: {"action":"infoDnsZone"`)
+		So(buf.String(), ShouldNotContainSubstring, apiKey)
+		So(buf.String(), ShouldNotContainSubstring, apiPassword)
+		So(buf.String(), ShouldNotContainSubstring, "SESSION_ID")
+	})
+
+	Convey("logs failed requests", t, func() {
+		buf := &bytes.Buffer{}
+		client, tearDown := setupClientTest(WithLogger(log.New(buf, "", 0)))
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			Reply(200).Type("application/json").
+			BodyString(`{"serverrequestid":"SERVER_REQUEST_ID","action":"infoDnsZone","status":"error","statuscode":5029,"shortmessage":"Domain not found"}`)
+
+		_, err := client.GetDnsZone(context.Background(), "domain.com")
+
+		So(err, ShouldNotBeNil)
+		So(buf.String(), ShouldContainSubstring, `[DEBUG] CCP API infoDnsZone failed after`)
+		So(buf.String(), ShouldContainSubstring, `server request ID "SERVER_REQUEST_ID", status code 5029): infoDnsZone failed with status code 5029`)
+	})
+
+	Convey("logs nothing without a logger", t, func() {
+		buf, restore := captureLog()
+		defer restore()
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			Reply(200).Type("application/json").
+			BodyString(`{"serverrequestid":"SERVER_REQUEST_ID","action":"infoDnsZone","status":"success","statuscode":2000,"responsedata":{"domainname":"domain.com"}}`)
+
+		_, err := client.GetDnsZone(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldBeEmpty)
+	})
+}
+
+func TestRedactBody(t *testing.T) {
+	Convey("replaces credentials and personal data at any depth", t, func() {
+		redactedBody := redactBody([]byte(`{"action":"createHandle","param":{"apikey":"KEY","apipassword":"PASSWORD","apisessionid":"SESSION","handles":[{"name":"Jane Doe","email":"jane@example.com","city":"Nuremberg","countrycode":"DE"}]}}`))
+
+		So(redactedBody, ShouldEqual, `{"action":"createHandle","param":{"apikey":"REDACTED","apipassword":"REDACTED","apisessionid":"REDACTED","handles":[{"city":"REDACTED","countrycode":"DE","email":"REDACTED","name":"REDACTED"}]}}`)
+	})
+
+	Convey("never returns bodies that are not JSON", t, func() {
+		So(redactBody([]byte(`apipassword=PASSWORD`)), ShouldEqual, "<20 bytes of non-JSON data>")
+	})
+}
+
+func TestHTTPError(t *testing.T) {
+	Convey("redacts the response body in its message", t, func() {
+		err := &HTTPError{StatusCode: 502, Body: `{"status":"error","responsedata":{"apisessionid":"SESSION"}}`}
+
+		So(err.Error(), ShouldEqual, `status: 502, body: {"responsedata":{"apisessionid":"REDACTED"},"status":"error"}`)
+		So((&HTTPError{StatusCode: 502, Body: "<html>apisessionid=SESSION</html>"}).Error(), ShouldEqual, "status: 502, body: <33 bytes of non-JSON data>")
+	})
+
+	Convey("truncates long response bodies in its message", t, func() {
+		err := &HTTPError{StatusCode: 500, Body: `{"longmessage":"` + strings.Repeat("x", 1000) + `"}`}
+
+		So(len(err.Error()), ShouldBeLessThan, 600)
+		So(err.Error(), ShouldEndWith, "...")
+	})
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultPollInterval is the time between two polls of the message queue while waiting for a request to complete.
+const DefaultPollInterval = 10 * time.Second
+
+// pollMessageCount is the number of queued messages first inspected per poll while waiting for a request to complete.
+// It is doubled whenever the queue holds more messages, see WaitForCompletion.
+const pollMessageCount = 100
+
+type (
+	pollRequest struct {
+		authData
+		MessageCount int `json:"messagecount"`
+	}
+
+	ackPollRequest struct {
+		authData
+		ApiLogId string `json:"apilogid"`
+	}
+
+	// PollMessage reports the outcome of an asynchronously processed request.
+	PollMessage struct {
+		ResponseBody
+		ApiLogId string `json:"apilogid"`
+	}
+
+	pollResponse struct {
+		ResponseBody
+		ResponseData []PollMessage `json:"responsedata"`
+	}
+)
+
+// Poll returns up to messageCount unacknowledged messages from the CCP message queue.
+func (c *CCPClient) Poll(ctx context.Context, messageCount int) ([]PollMessage, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(ctx, "poll", pollRequest{
+		authData:     c.authData,
+		MessageCount: messageCount,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := pollResponse{}
+	err = json.Unmarshal(body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.ResponseData, nil
+}
+
+// AckPoll removes the message with the given log ID from the CCP message queue.
+func (c *CCPClient) AckPoll(ctx context.Context, apiLogId string) error {
+	if err := c.ensureSession(ctx); err != nil {
+		return err
+	}
+	_, err := c.doRequest(ctx, "ackpoll", ackPollRequest{
+		authData: c.authData,
+		ApiLogId: apiLogId,
+	})
+	return err
+}
+
+// Matches reports whether the message belongs to the request with the given server or client request ID.
+func (m PollMessage) Matches(requestId string) bool {
+	return requestId != "" && (m.ServerRequestId == requestId || m.ClientRequestId == requestId)
+}
+
+// IsPending reports whether the request was accepted but has not been processed yet. The outcome of pending
+// requests is reported via the message queue.
+func (r ResponseBody) IsPending() bool {
+	return r.Status == "pending" || r.Status == "started"
+}
+
+// WaitForCompletion polls the message queue until the request with the given server or client request ID has been
+// processed, acknowledges all messages belonging to it and returns the final message. Messages of other requests are
+// left in the queue for their waiters, so a queue filled with them is polled with a growing message count until all
+// queued messages are inspected. An error is returned if the request failed or ctx is done before the request
+// completed.
+func (c *CCPClient) WaitForCompletion(ctx context.Context, requestId string) (*PollMessage, error) {
+	if requestId == "" {
+		return nil, fmt.Errorf("cannot wait for completion of a request without ID")
+	}
+
+	messageCount := pollMessageCount
+	for {
+		messages, err := c.Poll(ctx, messageCount)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, msg := range messages {
+			if !msg.Matches(requestId) {
+				continue
+			}
+			if err := c.AckPoll(ctx, msg.ApiLogId); err != nil {
+				return nil, err
+			}
+			if msg.IsPending() {
+				// intermediate status update, the final message is still to come
+				continue
+			}
+			m := msg
+			return &m, m.Err()
+		}
+
+		if len(messages) >= messageCount {
+			// the queue may hold more messages than were returned, inspect them right away
+			messageCount *= 2
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request %s did not complete: %w", requestId, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// Await waits for the completion of the request that produced res if it is still pending.
+func (c *CCPClient) Await(ctx context.Context, res *ResponseBody) error {
+	if res == nil || !res.IsPending() {
+		return nil
+	}
+	_, err := c.WaitForCompletion(ctx, res.ServerRequestId)
+	return err
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/h2non/gock.v1"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCCPClient_Poll(t *testing.T) {
+	Convey("returns queued messages", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"poll","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","messagecount":10}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[{"apilogid":"42","serverrequestid":"REQUEST_ID","action":"transferDomain","status":"success","statuscode":2000}]}`)
+
+		messages, err := client.Poll(context.Background(), 10)
+
+		So(err, ShouldBeNil)
+		So(messages, ShouldHaveLength, 1)
+		So(messages[0].ApiLogId, ShouldEqual, "42")
+		So(messages[0].Matches("REQUEST_ID"), ShouldBeTrue)
+		So(messages[0].Matches("OTHER_ID"), ShouldBeFalse)
+	})
+}
+
+func TestCCPClient_AckPoll(t *testing.T) {
+	Convey("acknowledges a message by its log ID", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").
+			BodyString(`{"action":"ackpoll","param":{"customernumber":"CUSTOMER_NUMBER","apikey":"API_KEY","apisessionid":"SESSION_ID","apilogid":"42"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success"}`)
+
+		So(client.AckPoll(context.Background(), "42"), ShouldBeNil)
+		So(gock.IsDone(), ShouldBeTrue)
+	})
+}
+
+func TestCCPClient_WaitForCompletion(t *testing.T) {
+	Convey("polls until the final message for the request arrives and acknowledges it", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+		client.pollInterval = time.Millisecond
+
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[{"apilogid":"1","serverrequestid":"OTHER_ID","status":"success"}]}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[{"apilogid":"1","serverrequestid":"OTHER_ID","status":"success"},{"apilogid":"2","serverrequestid":"REQUEST_ID","status":"success","statuscode":2000}]}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"ackpoll",.*"apilogid":"2"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success"}`)
+
+		msg, err := client.WaitForCompletion(context.Background(), "REQUEST_ID")
+
+		So(err, ShouldBeNil)
+		So(msg.ApiLogId, ShouldEqual, "2")
+		So(gock.IsDone(), ShouldBeTrue)
+	})
+
+	Convey("inspects all queued messages if more than one poll's worth of other messages are queued", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+		client.pollInterval = time.Hour
+
+		var others []string
+		for i := 0; i < pollMessageCount; i++ {
+			others = append(others, fmt.Sprintf(`{"apilogid":"%d","serverrequestid":"OTHER_ID","status":"success"}`, i))
+		}
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*"messagecount":100}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[` + strings.Join(others, ",") + `]}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*"messagecount":200}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[` + strings.Join(others, ",") + `,{"apilogid":"target","serverrequestid":"REQUEST_ID","status":"success","statuscode":2000}]}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"ackpoll",.*"apilogid":"target"}}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success"}`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		msg, err := client.WaitForCompletion(ctx, "REQUEST_ID")
+
+		So(err, ShouldBeNil)
+		So(msg.ApiLogId, ShouldEqual, "target")
+		So(gock.IsDone(), ShouldBeTrue)
+	})
+
+	Convey("returns the error reported for a failed request", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[{"apilogid":"2","serverrequestid":"REQUEST_ID","action":"createDomain","status":"error","statuscode":5029,"shortmessage":"Domain registration failed."}]}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"ackpoll",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success"}`)
+
+		_, err := client.WaitForCompletion(context.Background(), "REQUEST_ID")
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Domain registration failed.")
+	})
+
+	Convey("gives up when the context is done", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+		client.pollInterval = time.Hour
+
+		gock.New(HostURL).Post("").BodyString(`{"action":"poll",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"status":"success","responsedata":[]}`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForCompletion(ctx, "REQUEST_ID")
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "did not complete")
+	})
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecordStateActive is the state of a DNS record that has been published to netcup's nameservers.
+const RecordStateActive = "yes"
+
+// NetcupNameservers are the authoritative nameservers of all zones hosted by netcup.
+var NetcupNameservers = []string{"root-dns.netcup.net:53", "second-dns.netcup.net:53", "third-dns.netcup.net:53"}
+
+// WaitForDnsRecordActive polls the records of a domain until the record with the given ID is active.
+func (c *CCPClient) WaitForDnsRecordActive(ctx context.Context, domainName string, id string) (*DnsRecord, error) {
+	return PollDnsRecordActive(ctx, c, c.pollInterval, domainName, id)
+}
+
+// PollDnsRecordActive polls the records of a domain from dns every interval until the record with the given ID is
+// active.
+func PollDnsRecordActive(ctx context.Context, dns DNSService, interval time.Duration, domainName string, id string) (*DnsRecord, error) {
+	for {
+		record, err := dns.GetDnsRecordById(ctx, domainName, id)
+		if err != nil {
+			return nil, err
+		}
+		if record.State == RecordStateActive {
+			return record, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("DNS record with ID %s did not become active (state %q): %w", id, record.State, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// WaitForDnsRecordResolvable queries all given nameservers (as "host:port") until each of them answers with the
+// record, retrying every interval until ctx is done. Record types that cannot be checked (see IsResolvableType)
+// are rejected with an error.
+func WaitForDnsRecordResolvable(ctx context.Context, nameservers []string, interval time.Duration, domainName string, record DnsRecord) error {
+	if !IsResolvableType(record.Type) {
+		return fmt.Errorf("cannot check resolution of %s records", record.Type)
+	}
+
+	pending := append([]string{}, nameservers...)
+	for {
+		var remaining []string
+		var lastErr error
+		for _, nameserver := range pending {
+			ok, err := DnsRecordResolves(ctx, nameserver, domainName, record)
+			if err != nil {
+				lastErr = err
+			}
+			if !ok {
+				remaining = append(remaining, nameserver)
+			}
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		pending = remaining
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("DNS record %s %s not resolvable on %s: %s: %w", RecordFQDN(domainName, record.Hostname), record.Type, strings.Join(pending, ", "), lastErr, ctx.Err())
+			}
+			return fmt.Errorf("DNS record %s %s not resolvable on %s: %w", RecordFQDN(domainName, record.Hostname), record.Type, strings.Join(pending, ", "), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// IsResolvableType reports whether DnsRecordResolves can check records of the given type.
+func IsResolvableType(recordType string) bool {
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA", "CNAME", "MX", "NS", "SRV", "TXT":
+		return true
+	}
+	return false
+}
+
+// RecordFQDN returns the fully qualified name (with trailing dot) of a record hostname relative to its zone.
+func RecordFQDN(domainName string, hostname string) string {
+	zone := strings.TrimSuffix(domainName, ".") + "."
+	if hostname == "" || hostname == "@" {
+		return zone
+	}
+	return strings.TrimSuffix(hostname, ".") + "." + zone
+}
+
+// DnsRecordResolves asks the nameserver at the given address ("host:port") whether it answers with the record.
+func DnsRecordResolves(ctx context.Context, nameserver string, domainName string, record DnsRecord) (bool, error) {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, nameserver)
+		},
+	}
+
+	fqdn := RecordFQDN(domainName, record.Hostname)
+	destination := strings.TrimSuffix(record.Destination, ".")
+
+	switch strings.ToUpper(record.Type) {
+	case "A", "AAAA":
+		expected := net.ParseIP(destination)
+		addrs, err := resolver.LookupIPAddr(ctx, fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		for _, addr := range addrs {
+			if addr.IP.Equal(expected) {
+				return true, nil
+			}
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return strings.EqualFold(strings.TrimSuffix(cname, "."), destination), nil
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		for _, mx := range mxs {
+			if strings.EqualFold(strings.TrimSuffix(mx.Host, "."), destination) && priorityMatches(record.Priority, mx.Pref) {
+				return true, nil
+			}
+		}
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		for _, ns := range nss {
+			if strings.EqualFold(strings.TrimSuffix(ns.Host, "."), destination) {
+				return true, nil
+			}
+		}
+	case "SRV":
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		for _, srv := range srvs {
+			value := fmt.Sprintf("%d %d %s", srv.Weight, srv.Port, strings.TrimSuffix(srv.Target, "."))
+			if strings.EqualFold(value, destination) && priorityMatches(record.Priority, srv.Priority) {
+				return true, nil
+			}
+		}
+	case "TXT":
+		txts, err := resolver.LookupTXT(ctx, fqdn)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		for _, txt := range txts {
+			if txt == strings.Trim(record.Destination, `"`) {
+				return true, nil
+			}
+		}
+	default:
+		return false, fmt.Errorf("cannot check resolution of %s records", record.Type)
+	}
+	return false, nil
+}
+
+func priorityMatches(priority string, actual uint16) bool {
+	if priority == "" {
+		return true
+	}
+	p, err := strconv.Atoi(priority)
+	return err == nil && p == int(actual)
+}
+
+// ignoreNotFound hides errors for names that do not exist (yet), which just means the record is not resolvable.
+func ignoreNotFound(err error) error {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return nil
+	}
+	return err
+}
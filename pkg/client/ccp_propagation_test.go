@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/dns/dnsmessage"
+	"gopkg.in/h2non/gock.v1"
+	"net"
+	"testing"
+	"time"
+)
+
+// startDnsStub answers TXT queries for the given name with txt and all other queries with an empty answer.
+func startDnsStub(name string, txt string) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			q := query.Questions[0]
+			res := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+				Questions: query.Questions,
+			}
+			if q.Type == dnsmessage.TypeTXT && q.Name.String() == name {
+				res.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 300},
+					Body:   &dnsmessage.TXTResource{TXT: []string{txt}},
+				}}
+			}
+			packed, err := res.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+func TestCCPClient_WaitForDnsRecordActive(t *testing.T) {
+	Convey("polls the domain's records until the record is active", t, func() {
+		client, tearDown := setupClientTest()
+		defer tearDown()
+		client.pollInterval = time.Millisecond
+
+		gock.New(HostURL).Post("").BodyString(`{"action":"infoDnsRecords",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"dnsrecords":[{"id":"1","hostname":"www","type":"A","destination":"1.2.3.4","state":"unknown"}]}}`)
+		gock.New(HostURL).Post("").BodyString(`{"action":"infoDnsRecords",.*}`).
+			Reply(200).Type("application/json").
+			BodyString(`{"responsedata":{"dnsrecords":[{"id":"1","hostname":"www","type":"A","destination":"1.2.3.4","state":"yes"}]}}`)
+
+		record, err := client.WaitForDnsRecordActive(context.Background(), "domain.com", "1")
+
+		So(err, ShouldBeNil)
+		So(record.State, ShouldEqual, RecordStateActive)
+		So(gock.IsDone(), ShouldBeTrue)
+	})
+}
+
+func TestWaitForDnsRecordResolvable(t *testing.T) {
+	Convey("Given a nameserver answering with a TXT record", t, func() {
+		nameserver, stop := startDnsStub("_acme-challenge.domain.com.", "TOKEN")
+		defer stop()
+
+		Convey("succeeds once the record resolves", func() {
+			err := WaitForDnsRecordResolvable(context.Background(), []string{nameserver}, time.Millisecond, "domain.com", DnsRecord{
+				Hostname:    "_acme-challenge",
+				Type:        "TXT",
+				Destination: "TOKEN",
+			})
+
+			So(err, ShouldBeNil)
+		})
+
+		Convey("times out while the record has a different value", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := WaitForDnsRecordResolvable(ctx, []string{nameserver}, 10*time.Millisecond, "domain.com", DnsRecord{
+				Hostname:    "_acme-challenge",
+				Type:        "TXT",
+				Destination: "OTHER_TOKEN",
+			})
+
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, nameserver)
+		})
+	})
+
+	Convey("rejects record types that cannot be checked", t, func() {
+		err := WaitForDnsRecordResolvable(context.Background(), NetcupNameservers, time.Millisecond, "domain.com", DnsRecord{Type: "CAA"})
+
+		So(err, ShouldNotBeNil)
+	})
+}
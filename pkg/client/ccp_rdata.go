@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// SRVData is the destination of an SRV record, netcup stores its priority separately as the record's priority.
+	SRVData struct {
+		Weight int
+		Port   int
+		Target string
+	}
+
+	// CAAData is the destination of a CAA record.
+	CAAData struct {
+		Flags int
+		Tag   string
+		Value string
+	}
+
+	// TLSAData is the destination of a TLSA record, Data is the certificate association data in hex.
+	TLSAData struct {
+		Usage        int
+		Selector     int
+		MatchingType int
+		Data         string
+	}
+
+	// SSHFPData is the destination of an SSHFP record, Fingerprint is given in hex.
+	SSHFPData struct {
+		Algorithm   int
+		Type        int
+		Fingerprint string
+	}
+)
+
+// Destination returns the data in the format of the destination of a CCP DNS record, "weight port target".
+func (d SRVData) Destination() string {
+	return fmt.Sprintf("%d %d %s", d.Weight, d.Port, d.Target)
+}
+
+// ParseSRVData parses the destination of an SRV record.
+func ParseSRVData(destination string) (*SRVData, error) {
+	fields, err := destinationFields("SRV", destination, 3)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := parseNumbers("SRV", fields[:2])
+	if err != nil {
+		return nil, err
+	}
+	return &SRVData{Weight: numbers[0], Port: numbers[1], Target: fields[2]}, nil
+}
+
+// Destination returns the data in the format of the destination of a CCP DNS record, "flags tag value" with the value
+// quoted as a character string of RFC 1035.
+func (d CAAData) Destination() string {
+	return fmt.Sprintf("%d %s %s", d.Flags, d.Tag, quoteCharacterString(d.Value))
+}
+
+// ParseCAAData parses the destination of a CAA record. The value may be a quoted character string of RFC 1035 and
+// contain spaces.
+func ParseCAAData(destination string) (*CAAData, error) {
+	fields := strings.Fields(destination)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("CAA record destination %q requires 3 fields, got %d", destination, len(fields))
+	}
+	numbers, err := parseNumbers("CAA", fields[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	value := strings.TrimSpace(destination)
+	for _, field := range fields[:2] {
+		value = strings.TrimSpace(strings.TrimPrefix(value, field))
+	}
+	if strings.HasPrefix(value, `"`) {
+		unquoted, n, err := unquoteZoneString(value)
+		if err != nil {
+			return nil, fmt.Errorf("CAA record destination %q has an invalid quoted value: %w", destination, err)
+		}
+		if n != len(value) {
+			return nil, fmt.Errorf("CAA record destination %q has data after the quoted value", destination)
+		}
+		value = unquoted
+	}
+	return &CAAData{Flags: numbers[0], Tag: fields[1], Value: value}, nil
+}
+
+// Destination returns the data in the format of the destination of a CCP DNS record,
+// "usage selector matching_type data".
+func (d TLSAData) Destination() string {
+	return fmt.Sprintf("%d %d %d %s", d.Usage, d.Selector, d.MatchingType, d.Data)
+}
+
+// ParseTLSAData parses the destination of a TLSA record.
+func ParseTLSAData(destination string) (*TLSAData, error) {
+	fields, err := destinationFields("TLSA", destination, 4)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := parseNumbers("TLSA", fields[:3])
+	if err != nil {
+		return nil, err
+	}
+	return &TLSAData{Usage: numbers[0], Selector: numbers[1], MatchingType: numbers[2], Data: fields[3]}, nil
+}
+
+// Destination returns the data in the format of the destination of a CCP DNS record, "algorithm type fingerprint".
+func (d SSHFPData) Destination() string {
+	return fmt.Sprintf("%d %d %s", d.Algorithm, d.Type, d.Fingerprint)
+}
+
+// ParseSSHFPData parses the destination of an SSHFP record.
+func ParseSSHFPData(destination string) (*SSHFPData, error) {
+	fields, err := destinationFields("SSHFP", destination, 3)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := parseNumbers("SSHFP", fields[:2])
+	if err != nil {
+		return nil, err
+	}
+	return &SSHFPData{Algorithm: numbers[0], Type: numbers[1], Fingerprint: fields[2]}, nil
+}
+
+// destinationFields splits the destination of a record into exactly n fields.
+func destinationFields(recordType string, destination string, n int) ([]string, error) {
+	fields := strings.Fields(destination)
+	if len(fields) != n {
+		return nil, fmt.Errorf("%s record destination %q requires %d fields, got %d", recordType, destination, n, len(fields))
+	}
+	return fields, nil
+}
+
+// quoteCharacterString quotes s as a character string in the presentation format of RFC 1035, section 5.1. Quotes and
+// backslashes are escaped with a backslash, non-printable and non-ASCII bytes as \DDD.
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func parseNumbers(recordType string, fields []string) ([]int, error) {
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s record field %q is not a number", recordType, field)
+		}
+		numbers[i] = int(n)
+	}
+	return numbers, nil
+}
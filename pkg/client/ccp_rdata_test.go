@@ -0,0 +1,78 @@
+package client
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestRecordData(t *testing.T) {
+	Convey("SRV data is rendered without priority and parsed back", t, func() {
+		data := SRVData{Weight: 5, Port: 5060, Target: "sip.example.de"}
+		So(data.Destination(), ShouldEqual, "5 5060 sip.example.de")
+
+		parsed, err := ParseSRVData(data.Destination())
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, data)
+
+		_, err = ParseSRVData("10 5 5060 sip.example.de")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("CAA data is rendered with a quoted value and parsed back", t, func() {
+		data := CAAData{Flags: 0, Tag: "iodef", Value: "mailto:security@example.de"}
+		So(data.Destination(), ShouldEqual, `0 iodef "mailto:security@example.de"`)
+
+		parsed, err := ParseCAAData(data.Destination())
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, data)
+
+		parsed, err = ParseCAAData("128 issue letsencrypt.org")
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, CAAData{Flags: 128, Tag: "issue", Value: "letsencrypt.org"})
+
+		parsed, err = ParseCAAData("0  issue\t \"letsencrypt.org; validationmethods=dns-01\" ")
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, CAAData{Flags: 0, Tag: "issue", Value: "letsencrypt.org; validationmethods=dns-01"})
+
+		_, err = ParseCAAData("flags issue letsencrypt.org")
+		So(err, ShouldNotBeNil)
+		_, err = ParseCAAData(`0 issue "letsencrypt.org" extra`)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("CAA values with quotes and non-ASCII characters are escaped as in zone files", t, func() {
+		data := CAAData{Flags: 0, Tag: "iodef", Value: `mailto:"sécurité"@example.de`}
+		So(data.Destination(), ShouldEqual, `0 iodef "mailto:\"s\195\169curit\195\169\"@example.de"`)
+
+		parsed, err := ParseCAAData(data.Destination())
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, data)
+
+		zone, err := ParseZoneFile(strings.NewReader("caa IN CAA "+data.Destination()+"\n"), "example.de")
+		So(err, ShouldBeNil)
+		So(zone.Records, ShouldHaveLength, 1)
+		So(zone.Records[0].Destination, ShouldEqual, data.Destination())
+	})
+
+	Convey("TLSA data is rendered and parsed back", t, func() {
+		data := TLSAData{Usage: 3, Selector: 1, MatchingType: 1, Data: "0c72ac70b745ac19998811b131d662c9ac69dbdbe7cb23e5b514b56664c5d3d6"}
+		So(data.Destination(), ShouldEqual, "3 1 1 0c72ac70b745ac19998811b131d662c9ac69dbdbe7cb23e5b514b56664c5d3d6")
+
+		parsed, err := ParseTLSAData(data.Destination())
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, data)
+	})
+
+	Convey("SSHFP data is rendered and parsed back", t, func() {
+		data := SSHFPData{Algorithm: 4, Type: 2, Fingerprint: "123456789abcdef67890123456789abcdef67890123456789abcdef123456789"}
+		So(data.Destination(), ShouldEqual, "4 2 123456789abcdef67890123456789abcdef67890123456789abcdef123456789")
+
+		parsed, err := ParseSSHFPData(data.Destination())
+		So(err, ShouldBeNil)
+		So(*parsed, ShouldResemble, data)
+
+		_, err = ParseSSHFPData("4 2")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// DNSService manages the DNS zones and records of an account.
+type DNSService interface {
+	GetDnsZone(ctx context.Context, domainName string) (*DnsZone, error)
+	UpdateDnsZone(ctx context.Context, domainName string, zone DnsZone) (*DnsZone, error)
+	GetDnsRecords(ctx context.Context, domainName string) ([]DnsRecord, error)
+	GetDnsRecordById(ctx context.Context, domainName string, id string) (*DnsRecord, error)
+	CreateDnsRecord(ctx context.Context, domainName string, record NewDnsRecord) (*DnsRecord, error)
+	UpdateDnsRecord(ctx context.Context, domainName string, record DnsRecord) (*DnsRecord, error)
+	DeleteDnsRecord(ctx context.Context, domainName string, record DnsRecord) error
+	UpdateDnsRecords(ctx context.Context, domainName string, records []DnsRecord) ([]DnsRecord, error)
+}
+
+// DomainService registers, transfers and manages the domains of an account. Actions that are processed
+// asynchronously return the response to pass to Await.
+type DomainService interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	GetDomain(ctx context.Context, domainName string) (*Domain, error)
+	CreateDomain(ctx context.Context, domainName string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error)
+	UpdateDomain(ctx context.Context, domainName string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error)
+	CancelDomain(ctx context.Context, domainName string) (*ResponseBody, error)
+	TransferDomain(ctx context.Context, domainName string, authCode string, contacts DomainContacts, nameservers NameserverSet) (*ResponseBody, error)
+	GetAuthCode(ctx context.Context, domainName string) (string, error)
+	GetTopLevelDomainPrice(ctx context.Context, topLevelDomain string) (*TopLevelDomainPrice, error)
+	Await(ctx context.Context, res *ResponseBody) error
+}
+
+// AccountService describes the account a client acts for and the domain restrictions it was configured with.
+type AccountService interface {
+	CustomerNumber() string
+	Endpoint() string
+	AllowedDomains() []string
+	ForbiddenDomains() []string
+	ListDomains(ctx context.Context) ([]Domain, error)
+}
+
+// PollIntervalProvider is implemented by services that were configured with the interval for polling the state of
+// records and requests, see WithPollInterval.
+type PollIntervalProvider interface {
+	PollInterval() time.Duration
+}
+
+// PollIntervalOf returns the poll interval of service, DefaultPollInterval if it does not provide one.
+func PollIntervalOf(service interface{}) time.Duration {
+	if p, ok := service.(PollIntervalProvider); ok && p.PollInterval() > 0 {
+		return p.PollInterval()
+	}
+	return DefaultPollInterval
+}
+
+var (
+	_ DNSService           = (*CCPClient)(nil)
+	_ DomainService        = (*CCPClient)(nil)
+	_ AccountService       = (*CCPClient)(nil)
+	_ PollIntervalProvider = (*CCPClient)(nil)
+)
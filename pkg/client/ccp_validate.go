@@ -0,0 +1,101 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DnsRecordConflict is a violation of the DNS rules by a record of a zone, which netcup either rejects when the
+// records are changed or accepts and serves in a broken way.
+type DnsRecordConflict struct {
+	Record DnsRecord
+	// Other is the record that Record conflicts with, nil if Record is invalid on its own.
+	Other  *DnsRecord
+	Reason string
+}
+
+func (c DnsRecordConflict) Error() string {
+	if c.Other == nil {
+		return fmt.Sprintf("%s record %s with value %q: %s", c.Record.Type, c.Record.Hostname, c.Record.Destination, c.Reason)
+	}
+	return fmt.Sprintf("%s record %s with value %q conflicts with %s record %s with value %q: %s",
+		c.Record.Type, c.Record.Hostname, c.Record.Destination, c.Other.Type, c.Other.Hostname, c.Other.Destination, c.Reason)
+}
+
+// CheckDnsRecords checks the records of a zone for conflicts:
+//
+//   - a CNAME record must be the only record of its hostname (RFC 1034 section 3.6.2, RFC 2181 section 10.1),
+//   - the zone apex cannot have a CNAME record, because it has SOA and NS records,
+//   - MX and NS records must not point to a hostname of the zone that has a CNAME record (RFC 2181 section 10.3).
+//
+// Each conflicting pair of records is reported once, in the order of records.
+func CheckDnsRecords(domainName string, records []DnsRecord) []DnsRecordConflict {
+	var conflicts []DnsRecordConflict
+	for i := range records {
+		conflicts = append(conflicts, CheckDnsRecord(domainName, records[:i], records[i])...)
+	}
+	return conflicts
+}
+
+// CheckDnsRecord checks a single record for the conflicts described at CheckDnsRecords with the other records of the
+// zone. zone must not contain the record itself.
+func CheckDnsRecord(domainName string, zone []DnsRecord, record DnsRecord) []DnsRecordConflict {
+	var conflicts []DnsRecordConflict
+	if isCNAME(record) && normalizeHostname(record.Hostname) == "@" {
+		conflicts = append(conflicts, DnsRecordConflict{
+			Record: record,
+			Reason: "the zone apex cannot have a CNAME record",
+		})
+	}
+
+	for i := range zone {
+		other := &zone[i]
+		switch {
+		case isCNAME(record) && sameHostname(record, *other):
+			conflicts = append(conflicts, DnsRecordConflict{
+				Record: record,
+				Other:  other,
+				Reason: "a hostname with a CNAME record cannot have other records",
+			})
+		case isCNAME(*other) && sameHostname(record, *other):
+			conflicts = append(conflicts, DnsRecordConflict{
+				Record: record,
+				Other:  other,
+				Reason: "a hostname with a CNAME record cannot have other records",
+			})
+		case isCNAME(record) && pointsTo(domainName, *other, record):
+			conflicts = append(conflicts, DnsRecordConflict{
+				Record: *other,
+				Other:  &record,
+				Reason: other.Type + " records must not point to a hostname with a CNAME record",
+			})
+		case isCNAME(*other) && pointsTo(domainName, record, *other):
+			conflicts = append(conflicts, DnsRecordConflict{
+				Record: record,
+				Other:  other,
+				Reason: record.Type + " records must not point to a hostname with a CNAME record",
+			})
+		}
+	}
+	return conflicts
+}
+
+func isCNAME(record DnsRecord) bool {
+	return strings.EqualFold(record.Type, "CNAME")
+}
+
+func sameHostname(r1 DnsRecord, r2 DnsRecord) bool {
+	return strings.EqualFold(normalizeHostname(r1.Hostname), normalizeHostname(r2.Hostname))
+}
+
+// pointsTo reports whether record is an MX or NS record whose destination is the hostname of target. Relative
+// destinations are resolved against the zone, see qualifyDestination.
+func pointsTo(domainName string, record DnsRecord, target DnsRecord) bool {
+	switch strings.ToUpper(record.Type) {
+	case "MX", "NS":
+	default:
+		return false
+	}
+	hostname, ok := relativeName(qualifyDestination(record.Destination, domainName), canonicalName(domainName))
+	return ok && strings.EqualFold(hostname, normalizeHostname(target.Hostname))
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestCheckDnsRecords(t *testing.T) {
+	Convey("Given the records of a zone", t, func() {
+		Convey("records without conflicts are accepted", func() {
+			conflicts := CheckDnsRecords("example.com", []DnsRecord{
+				{Hostname: "@", Type: "A", Destination: "1.2.3.4"},
+				{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com"},
+				{Hostname: "mail", Type: "A", Destination: "1.2.3.4"},
+				{Hostname: "www", Type: "CNAME", Destination: "example.com"},
+			})
+
+			So(conflicts, ShouldBeEmpty)
+		})
+
+		Convey("a CNAME record next to other records of its hostname is a conflict", func() {
+			conflicts := CheckDnsRecords("example.com", []DnsRecord{
+				{Hostname: "www", Type: "A", Destination: "1.2.3.4"},
+				{Hostname: "WWW", Type: "CNAME", Destination: "example.com"},
+			})
+
+			So(conflicts, ShouldHaveLength, 1)
+			So(conflicts[0].Record.Type, ShouldEqual, "CNAME")
+			So(conflicts[0].Other.Type, ShouldEqual, "A")
+			So(conflicts[0].Error(), ShouldEqual, `CNAME record WWW with value "example.com" conflicts with A record www with value "1.2.3.4": a hostname with a CNAME record cannot have other records`)
+		})
+
+		Convey("a CNAME record at the zone apex is a conflict", func() {
+			conflicts := CheckDnsRecords("example.com", []DnsRecord{
+				{Hostname: "@", Type: "CNAME", Destination: "other.example.org"},
+			})
+
+			So(conflicts, ShouldHaveLength, 1)
+			So(conflicts[0].Other, ShouldBeNil)
+		})
+
+		Convey("MX and NS records pointing to a hostname with a CNAME record are conflicts", func() {
+			conflicts := CheckDnsRecords("example.com", []DnsRecord{
+				{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.example.com."},
+				{Hostname: "mail", Type: "CNAME", Destination: "mx.example.org"},
+				{Hostname: "sub", Type: "NS", Destination: "Mail.Example.com"},
+				{Hostname: "other", Type: "MX", Priority: "10", Destination: "mail.example.org"},
+			})
+
+			So(conflicts, ShouldHaveLength, 2)
+			So(conflicts[0].Record.Type, ShouldEqual, "MX")
+			So(conflicts[0].Other.Hostname, ShouldEqual, "mail")
+			So(conflicts[1].Record.Type, ShouldEqual, "NS")
+		})
+
+		Convey("relative destinations are resolved against the zone", func() {
+			conflicts := CheckDnsRecords("example.com", []DnsRecord{
+				{Hostname: "mail", Type: "CNAME", Destination: "mx.example.org"},
+				{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail"},
+				{Hostname: "ns", Type: "CNAME", Destination: "ns.example.org"},
+				{Hostname: "sub", Type: "NS", Destination: "ns"},
+			})
+
+			So(conflicts, ShouldHaveLength, 2)
+			So(conflicts[0].Record.Type, ShouldEqual, "MX")
+			So(conflicts[1].Record.Type, ShouldEqual, "NS")
+		})
+	})
+}
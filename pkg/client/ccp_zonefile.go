@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// SOAPrimaryNameserver is the primary nameserver in the SOA record of netcup zones.
+	SOAPrimaryNameserver = "root-dns.netcup.net."
+	// SOAHostmaster is the mailbox of the person responsible for netcup zones in the SOA record.
+	SOAHostmaster = "hostmaster.netcup.net."
+
+	// maxTXTStringLength is the maximum length of a single character string of a TXT record.
+	maxTXTStringLength = 255
+)
+
+// ExportZoneFile retrieves a DNS zone and its records and formats them as a zone file.
+func (c *CCPClient) ExportZoneFile(ctx context.Context, domainName string) (string, error) {
+	return ExportDnsZone(ctx, c, domainName)
+}
+
+// ExportDnsZone retrieves a DNS zone and its records from dns and formats them as a zone file.
+func ExportDnsZone(ctx context.Context, dns DNSService, domainName string) (string, error) {
+	zone, err := dns.GetDnsZone(ctx, domainName)
+	if err != nil {
+		return "", err
+	}
+
+	records, err := dns.GetDnsRecords(ctx, domainName)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatZoneFile(*zone, records), nil
+}
+
+// FormatZoneFile formats a DNS zone and its records as an RFC 1035 zone file. The SOA record is derived from the
+// zone's TTL, serial, refresh, retry and expire values, the zone TTL is also used as negative caching TTL. NS records
+// for netcup's nameservers are added at the zone apex. Records are sorted by hostname, type and destination so that
+// exports of the same zone can be compared with diff.
+func FormatZoneFile(zone DnsZone, records []DnsRecord) string {
+	origin := strings.TrimSuffix(zone.Name, ".") + "."
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(&b, "$TTL %s\n", zone.TTL)
+	fmt.Fprintf(&b, "@\tIN\tSOA\t%s %s (\n", SOAPrimaryNameserver, SOAHostmaster)
+	fmt.Fprintf(&b, "\t\t\t%s ; serial\n", zone.Serial)
+	fmt.Fprintf(&b, "\t\t\t%s ; refresh\n", zone.Refresh)
+	fmt.Fprintf(&b, "\t\t\t%s ; retry\n", zone.Retry)
+	fmt.Fprintf(&b, "\t\t\t%s ; expire\n", zone.Expire)
+	fmt.Fprintf(&b, "\t\t\t%s ) ; minimum\n", zone.TTL)
+	for _, nameserver := range NetcupNameservers {
+		host := strings.TrimSuffix(nameserver, ":53")
+		fmt.Fprintf(&b, "@\tIN\tNS\t%s.\n", host)
+	}
+
+	sorted := make([]DnsRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		r1, r2 := sorted[i], sorted[j]
+		if r1.Hostname != r2.Hostname {
+			// the zone apex comes first
+			return r1.Hostname == "@" || (r2.Hostname != "@" && r1.Hostname < r2.Hostname)
+		}
+		if r1.Type != r2.Type {
+			return r1.Type < r2.Type
+		}
+		return r1.Destination < r2.Destination
+	})
+
+	for _, record := range sorted {
+		fmt.Fprintf(&b, "%s\tIN\t%s\t%s\n", record.Hostname, strings.ToUpper(record.Type), formatRData(record))
+	}
+
+	return b.String()
+}
+
+// formatRData returns the data of a record in zone file presentation format.
+func formatRData(record DnsRecord) string {
+	switch strings.ToUpper(record.Type) {
+	case "MX":
+		return priorityOrZero(record.Priority) + " " + absoluteName(record.Destination)
+	case "SRV":
+		// netcup stores "weight port target" as destination of SRV records
+		fields := strings.Fields(record.Destination)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = absoluteName(fields[len(fields)-1])
+		}
+		return priorityOrZero(record.Priority) + " " + strings.Join(fields, " ")
+	case "CNAME", "NS", "PTR":
+		return absoluteName(record.Destination)
+	case "TXT":
+		return quoteTXT(record.Destination)
+	default:
+		return record.Destination
+	}
+}
+
+// absoluteName turns a domain name with at least one dot into a fully qualified name. Names without a dot and "@"
+// are relative to the zone origin and left unchanged. ParseZoneFile reverses it, see destinationName.
+func absoluteName(name string) string {
+	if name == "@" || strings.HasSuffix(name, ".") || !strings.Contains(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quoteTXT quotes the text of a TXT record, splitting it into character strings of at most 255 bytes.
+func quoteTXT(text string) string {
+	if text == "" {
+		return `""`
+	}
+
+	var parts []string
+	for len(text) > 0 {
+		n := len(text)
+		if n > maxTXTStringLength {
+			n = maxTXTStringLength
+		}
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text[:n])
+		parts = append(parts, `"`+escaped+`"`)
+		text = text[n:]
+	}
+	return strings.Join(parts, " ")
+}
+
+func priorityOrZero(priority string) string {
+	if priority == "" {
+		return "0"
+	}
+	return priority
+}
@@ -0,0 +1,351 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ZoneFile is the result of parsing a zone file.
+type ZoneFile struct {
+	// Records are the records of the zone that netcup supports, with hostnames relative to the zone.
+	Records []DnsRecord
+	// Warnings describe the records of the zone file that were ignored.
+	Warnings []string
+}
+
+type zoneEntry struct {
+	line   int
+	tokens []string
+	// ownerOmitted is set if the entry starts with whitespace and belongs to the previous owner
+	ownerOmitted bool
+}
+
+// ParseZoneFile parses an RFC 1035 zone file of the zone domainName. Names are relativized to the zone, "@" denotes
+// the zone apex. Destinations that are domain names are returned in the form FormatZoneFile writes them, see
+// destinationName, so that importing an exported zone changes nothing. The SOA
+// record, NS records at the apex, records outside of the zone and records of types netcup does not support are
+// ignored and reported as warnings. Record TTLs and classes are ignored.
+func ParseZoneFile(r io.Reader, domainName string) (*ZoneFile, error) {
+	entries, err := scanZoneEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := canonicalName(domainName)
+	origin := zone
+	owner := ""
+	result := &ZoneFile{}
+
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN requires exactly one domain name", entry.line)
+			}
+			origin = qualifyName(tokens[1], origin)
+			continue
+		case "$TTL":
+			continue
+		case "$INCLUDE":
+			return nil, fmt.Errorf("line %d: $INCLUDE is not supported", entry.line)
+		}
+
+		if !entry.ownerOmitted {
+			owner = qualifyName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner name", entry.line)
+		}
+
+		// TTL and class may precede the type in any order
+		for len(tokens) > 0 && (isTTL(tokens[0]) || isClass(tokens[0])) {
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", entry.line)
+		}
+		recordType := strings.ToUpper(tokens[0])
+		rdata := tokens[1:]
+
+		hostname, ok := relativeName(owner, zone)
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: ignored %s record for %s outside of zone %s", entry.line, recordType, owner, strings.TrimSuffix(zone, ".")))
+			continue
+		}
+
+		record, warning, err := zoneRecord(hostname, recordType, rdata, origin, zone)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", entry.line, err)
+		}
+		if warning != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: %s", entry.line, warning))
+			continue
+		}
+		result.Records = append(result.Records, *record)
+	}
+
+	return result, nil
+}
+
+// zoneRecord maps a resource record onto a DnsRecord. Records that cannot be managed at netcup are reported with a
+// warning instead.
+func zoneRecord(hostname string, recordType string, rdata []string, origin string, zone string) (*DnsRecord, string, error) {
+	expectFields := func(n int) error {
+		if len(rdata) != n {
+			return fmt.Errorf("%s record requires %d fields, got %d", recordType, n, len(rdata))
+		}
+		return nil
+	}
+
+	record := &DnsRecord{Hostname: hostname, Type: recordType}
+
+	switch recordType {
+	case "SOA":
+		return nil, "ignored SOA record, netcup manages the SOA record of its zones", nil
+	case "NS":
+		if err := expectFields(1); err != nil {
+			return nil, "", err
+		}
+		if hostname == "@" {
+			return nil, "ignored NS record at the zone apex, netcup manages the nameservers of its zones", nil
+		}
+		record.Destination = destinationName(rdata[0], origin, zone)
+	case "A", "AAAA", "OPENPGPKEY":
+		if err := expectFields(1); err != nil {
+			return nil, "", err
+		}
+		record.Destination = rdata[0]
+	case "CNAME":
+		if err := expectFields(1); err != nil {
+			return nil, "", err
+		}
+		record.Destination = destinationName(rdata[0], origin, zone)
+	case "MX":
+		if err := expectFields(2); err != nil {
+			return nil, "", err
+		}
+		record.Priority = rdata[0]
+		record.Destination = destinationName(rdata[1], origin, zone)
+	case "SRV":
+		if err := expectFields(4); err != nil {
+			return nil, "", err
+		}
+		record.Priority = rdata[0]
+		record.Destination = strings.Join([]string{rdata[1], rdata[2], destinationName(rdata[3], origin, zone)}, " ")
+	case "TXT":
+		if len(rdata) == 0 {
+			return nil, "", fmt.Errorf("TXT record requires at least one string")
+		}
+		record.Destination = strings.Join(rdata, "")
+	case "CAA":
+		if err := expectFields(3); err != nil {
+			return nil, "", err
+		}
+		record.Destination = fmt.Sprintf("%s %s %s", rdata[0], rdata[1], quoteCharacterString(rdata[2]))
+	case "DS", "TLSA", "SSHFP", "SMIMEA":
+		if len(rdata) == 0 {
+			return nil, "", fmt.Errorf("%s record requires data", recordType)
+		}
+		record.Destination = strings.Join(rdata, " ")
+	default:
+		return nil, fmt.Sprintf("ignored %s record for %s, the record type is not supported by netcup", recordType, hostname), nil
+	}
+
+	return record, "", nil
+}
+
+// scanZoneEntries splits a zone file into entries of tokens, joining lines within parentheses and removing comments.
+func scanZoneEntries(r io.Reader) ([]zoneEntry, error) {
+	var entries []zoneEntry
+	var current *zoneEntry
+	depth := 0
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+
+		if depth == 0 {
+			if current != nil && len(current.tokens) > 0 {
+				entries = append(entries, *current)
+			}
+			current = &zoneEntry{
+				line:         lineNumber,
+				ownerOmitted: len(line) > 0 && (line[0] == ' ' || line[0] == '\t'),
+			}
+		}
+
+		tokens, delta, err := tokenizeZoneLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		depth += delta
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+		}
+		current.tokens = append(current.tokens, tokens...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses at end of zone file")
+	}
+	if current != nil && len(current.tokens) > 0 {
+		entries = append(entries, *current)
+	}
+
+	return entries, nil
+}
+
+// tokenizeZoneLine splits a line into tokens and returns the change of the parenthesis depth.
+func tokenizeZoneLine(line string) ([]string, int, error) {
+	var tokens []string
+	depth := 0
+
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ';':
+			return tokens, depth, nil
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+		case c == '"':
+			text, n, err := unquoteZoneString(line[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			tokens = append(tokens, text)
+			i += n
+		default:
+			start := i
+			for i < len(line) && !strings.ContainsRune(" \t\r;()\"", rune(line[i])) {
+				if line[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i > len(line) {
+				i = len(line)
+			}
+			tokens = append(tokens, line[start:i])
+		}
+	}
+
+	return tokens, depth, nil
+}
+
+// unquoteZoneString decodes the quoted string at the start of s and returns it with the number of bytes consumed.
+func unquoteZoneString(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				// \DDD is the octet with decimal value DDD
+				n, _ := strconv.Atoi(s[i+1 : i+4])
+				b.WriteByte(byte(n))
+				i += 3
+			} else if i+1 < len(s) {
+				b.WriteByte(s[i+1])
+				i++
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// canonicalName returns a domain name in lower case with trailing dot.
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+// destinationName returns the target name of a record in the canonical form of destinations, the inverse of
+// absoluteName: "@" and names without a dot that are relative to the zone itself stay relative, all other names are
+// returned fully qualified, without trailing dot.
+func destinationName(name string, origin string, zone string) string {
+	if canonicalName(origin) == zone && (name == "@" || !strings.Contains(name, ".")) {
+		return name
+	}
+	return qualifyName(name, origin)
+}
+
+// qualifyDestination returns a destination in the canonical form of destinations, see destinationName, as fully
+// qualified name of the zone domainName.
+func qualifyDestination(destination string, domainName string) string {
+	if destination == "@" || !strings.Contains(destination, ".") {
+		return qualifyName(destination, canonicalName(domainName))
+	}
+	return destination
+}
+
+// qualifyName returns a name as fully qualified domain name without trailing dot. Relative names are relative to
+// origin, "@" denotes the origin itself.
+func qualifyName(name string, origin string) string {
+	switch {
+	case name == "@":
+		return strings.TrimSuffix(origin, ".")
+	case name == ".":
+		return name
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + strings.TrimSuffix(origin, ".")
+	}
+}
+
+// relativeName returns a fully qualified name relative to zone, or false if the name is not part of the zone.
+func relativeName(name string, zone string) (string, bool) {
+	name = canonicalName(name)
+	if name == zone {
+		return "@", true
+	}
+	if strings.HasSuffix(name, "."+zone) {
+		return strings.TrimSuffix(name, "."+zone), true
+	}
+	return "", false
+}
+
+func isTTL(s string) bool {
+	// TTLs are given in seconds or with units such as 1h30m
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return false
+	}
+	for _, c := range strings.ToLower(s) {
+		if !strings.ContainsRune("0123456789smhdw", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
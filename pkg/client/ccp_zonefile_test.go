@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rincedd/terraform-provider-netcup-ccp/internal/ccpfake"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormatZoneFile(t *testing.T) {
+	Convey("formats a zone with SOA, NS and sorted records", t, func() {
+		zone := DnsZone{Name: "domain.com", TTL: "86400", Serial: "2021010101", Refresh: "28800", Retry: "7200", Expire: "1209600"}
+		records := []DnsRecord{
+			{Id: "1", Hostname: "www", Type: "CNAME", Destination: "@"},
+			{Id: "2", Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.domain.com"},
+			{Id: "3", Hostname: "_sip._tcp", Type: "SRV", Priority: "5", Destination: "0 5060 sip.provider.net"},
+			{Id: "4", Hostname: "@", Type: "A", Destination: "1.2.3.4"},
+			{Id: "5", Hostname: "blog", Type: "CNAME", Destination: "pages.github.io"},
+			{Id: "6", Hostname: "@", Type: "TXT", Destination: `v=spf1 include:"quoted" -all`},
+		}
+
+		So(FormatZoneFile(zone, records), ShouldEqual, `$ORIGIN domain.com.
+$TTL 86400
+@	IN	SOA	root-dns.netcup.net. hostmaster.netcup.net. (
+			2021010101 ; serial
+			28800 ; refresh
+			7200 ; retry
+			1209600 ; expire
+			86400 ) ; minimum
+@	IN	NS	root-dns.netcup.net.
+@	IN	NS	second-dns.netcup.net.
+@	IN	NS	third-dns.netcup.net.
+@	IN	A	1.2.3.4
+@	IN	MX	10 mail.domain.com.
+@	IN	TXT	"v=spf1 include:\"quoted\" -all"
+_sip._tcp	IN	SRV	5 0 5060 sip.provider.net.
+blog	IN	CNAME	pages.github.io.
+www	IN	CNAME	@
+`)
+	})
+
+	Convey("splits long TXT records into strings of 255 bytes", t, func() {
+		key := strings.Repeat("a", 300)
+
+		So(quoteTXT(key), ShouldEqual, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`)
+	})
+}
+
+func TestCCPClient_ExportZoneFile(t *testing.T) {
+	Convey("exports a zone of the fake CCP API", t, func() {
+		client, fake, tearDown := setupFakeClientTest()
+		defer tearDown()
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "www", Type: "A", Destination: "1.2.3.4"})
+
+		zoneFile, err := client.ExportZoneFile(context.Background(), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(zoneFile, ShouldStartWith, "$ORIGIN domain.com.\n")
+		So(zoneFile, ShouldEndWith, "www\tIN\tA\t1.2.3.4\n")
+	})
+
+	Convey("plans no changes when importing an exported zone", t, func() {
+		client, fake, tearDown := setupFakeClientTest()
+		defer tearDown()
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "blog", Type: "CNAME", Destination: "pages.github.io"})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "docs", Type: "CNAME", Destination: "Docs.Example.org."})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "www", Type: "CNAME", Destination: "@"})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "@", Type: "MX", Priority: "20", Destination: "mail"})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "@", Type: "MX", Priority: "10", Destination: "mx.other.net"})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "_sip._tcp", Type: "SRV", Priority: "5", Destination: "0 5060 sip.provider.net."})
+		fake.AddRecord("domain.com", ccpfake.Record{Hostname: "@", Type: "TXT", Destination: `"v=spf1 -all"`})
+
+		exported, err := client.ExportZoneFile(context.Background(), "domain.com")
+		So(err, ShouldBeNil)
+		zoneFile, err := ParseZoneFile(strings.NewReader(exported), "domain.com")
+		So(err, ShouldBeNil)
+		existing, err := client.GetDnsRecords(context.Background(), "domain.com")
+		So(err, ShouldBeNil)
+
+		So(DnsRecordChanges(existing, zoneFile.Records, func(DnsRecord) bool { return true }), ShouldBeEmpty)
+	})
+}
+
+func TestParseZoneFile(t *testing.T) {
+	Convey("parses records and relativizes names to the zone", t, func() {
+		zoneFile, err := ParseZoneFile(strings.NewReader(`$ORIGIN domain.com.
+$TTL 3600
+@	IN	SOA	ns1.other.net. hostmaster.domain.com. (
+			2021010101 ; serial
+			3600 900 604800 300 )
+	IN	NS	ns1.other.net.
+@	300	IN	A	1.2.3.4
+	IN	MX	10 mail
+www	CNAME	@
+_sip._tcp.domain.com.	IN	SRV	5 0 5060 sip.provider.net.
+txt	IN	TXT	"v=spf1 " "include:\"quoted\" -all" ; comment
+caa	IN	CAA	0 issue "letsencrypt.org"
+host	IN	HINFO	"PC" "Linux"
+other.net.	IN	A	5.6.7.8
+$ORIGIN sub.domain.com.
+deep	1h	IN	AAAA	::1
+alias	IN	CNAME	deep
+`), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(zoneFile.Records, ShouldResemble, []DnsRecord{
+			{Hostname: "@", Type: "A", Destination: "1.2.3.4"},
+			{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail"},
+			{Hostname: "www", Type: "CNAME", Destination: "@"},
+			{Hostname: "_sip._tcp", Type: "SRV", Priority: "5", Destination: "0 5060 sip.provider.net"},
+			{Hostname: "txt", Type: "TXT", Destination: `v=spf1 include:"quoted" -all`},
+			{Hostname: "caa", Type: "CAA", Destination: `0 issue "letsencrypt.org"`},
+			{Hostname: "deep.sub", Type: "AAAA", Destination: "::1"},
+			{Hostname: "alias.sub", Type: "CNAME", Destination: "deep.sub.domain.com"},
+		})
+		So(zoneFile.Warnings, ShouldHaveLength, 4)
+		So(zoneFile.Warnings[0], ShouldStartWith, "line 3: ignored SOA record")
+		So(zoneFile.Warnings[1], ShouldStartWith, "line 6: ignored NS record at the zone apex")
+		So(zoneFile.Warnings[2], ShouldStartWith, "line 13: ignored HINFO record for host")
+		So(zoneFile.Warnings[3], ShouldStartWith, "line 14: ignored A record for other.net outside of zone domain.com")
+	})
+
+	Convey("round-trips formatted zone files", t, func() {
+		zone := DnsZone{Name: "domain.com", TTL: "86400", Serial: "1", Refresh: "28800", Retry: "7200", Expire: "1209600"}
+		records := []DnsRecord{
+			{Hostname: "@", Type: "MX", Priority: "20", Destination: "backup"},
+			{Hostname: "@", Type: "MX", Priority: "10", Destination: "mail.domain.com"},
+			{Hostname: "_sip._tcp", Type: "SRV", Priority: "5", Destination: "0 5060 sip"},
+			{Hostname: "long", Type: "TXT", Destination: strings.Repeat("x", 300)},
+			{Hostname: "www", Type: "CNAME", Destination: "@"},
+		}
+
+		zoneFile, err := ParseZoneFile(strings.NewReader(FormatZoneFile(zone, records)), "domain.com")
+
+		So(err, ShouldBeNil)
+		So(zoneFile.Records, ShouldResemble, records)
+	})
+
+	Convey("rejects malformed records", t, func() {
+		_, err := ParseZoneFile(strings.NewReader("@ IN MX mail.domain.com.\n"), "domain.com")
+
+		So(err, ShouldBeError, "line 1: MX record requires 2 fields, got 1")
+	})
+}
+
+func TestCCPClient_UpdateDnsRecords(t *testing.T) {
+	Convey("creates and deletes records in a single request", t, func() {
+		client, fake, tearDown := setupFakeClientTest()
+		defer tearDown()
+		existing, _ := fake.AddRecord("domain.com", ccpfake.Record{Hostname: "old", Type: "A", Destination: "1.2.3.4"})
+
+		records, err := client.UpdateDnsRecords(context.Background(), "domain.com", []DnsRecord{
+			{Id: existing.Id, Hostname: "old", Type: "A", Destination: "1.2.3.4", DeleteRecord: true},
+			{Hostname: "new", Type: "A", Destination: "5.6.7.8"},
+		})
+
+		So(err, ShouldBeNil)
+		So(records, ShouldHaveLength, 1)
+		So(records[0].Matches(DnsRecord{Hostname: "new", Type: "a", Destination: "5.6.7.8"}), ShouldBeTrue)
+	})
+}
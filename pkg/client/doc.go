@@ -0,0 +1,16 @@
+// Package client is a Go client for the DNS and domain actions of the netcup CCP API. It is used by the Terraform
+// provider, the netcup-ccp command and the acme package, and can be imported by other programs:
+//
+//	c, err := client.NewCCPClient(customerNumber, apiKey, apiPassword, client.WithLazyLogin())
+//	if err != nil {
+//		return err
+//	}
+//	records, err := c.GetDnsRecords(ctx, "example.com")
+//
+// All API methods take a context that cancels the request. Failed API calls return an *APIError, failed HTTP requests
+// an *HTTPError, so callers can inspect them with errors.As. DNSService and DomainService describe the methods of
+// CCPClient for callers that want to substitute it, e.g. in tests.
+//
+// The package follows semantic versioning with the tags of its module: within a major version, exported identifiers
+// are neither removed nor changed incompatibly. Changes are listed in the CHANGELOG of the repository.
+package client
@@ -0,0 +1,247 @@
+// Package credentials resolves CCP API credentials from sources other than the provider configuration: shared
+// credentials files with named profiles, external credential helpers and password files. It is shared by the
+// provider, the netcup-ccp command and the acme package, so all of them read the same sources.
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the profile read from a credentials file if no profile is given.
+const DefaultProfile = "default"
+
+// Credentials are the credentials required to log in to the CCP API. The same keys are used in credentials files
+// and in the output of credential helpers.
+type Credentials struct {
+	CustomerNumber string `json:"customer_number"`
+	APIKey         string `json:"api_key"`
+	APIPassword    string `json:"api_password"`
+}
+
+// Complete reports whether all credentials are set.
+func (c Credentials) Complete() bool {
+	return c.CustomerNumber != "" && c.APIKey != "" && c.APIPassword != ""
+}
+
+// missing returns the names of the credentials that are not set.
+func (c Credentials) missing() []string {
+	var missing []string
+	if c.CustomerNumber == "" {
+		missing = append(missing, "customer number")
+	}
+	if c.APIKey == "" {
+		missing = append(missing, "API key")
+	}
+	if c.APIPassword == "" {
+		missing = append(missing, "API password")
+	}
+	return missing
+}
+
+// complete returns c if all credentials are set and an error naming the missing ones and their source otherwise.
+func (c Credentials) complete(source string) (Credentials, error) {
+	if missing := c.missing(); len(missing) > 0 {
+		return Credentials{}, fmt.Errorf("incomplete credentials from %s: %s missing", source, strings.Join(missing, ", "))
+	}
+	return c, nil
+}
+
+// Sources are the places credentials are read from, see Resolve.
+type Sources struct {
+	// Explicit are credentials given directly, e.g. by configuration or environment variables.
+	Explicit Credentials
+	// PasswordFile is the path of a file containing the API password.
+	PasswordFile string
+	// Command is a credential helper to run, see FromCommand.
+	Command []string
+	// File is the path of a shared credentials file. Defaults to DefaultFile.
+	File string
+	// Profile is the profile to read from the shared credentials file. Defaults to DefaultProfile.
+	Profile string
+}
+
+// Resolve returns the credentials of the first of these sources that provides any of them:
+//
+//  1. the explicit credentials, with the API password read from the password file unless it is given
+//  2. the output of the credential helper
+//  3. the profile of the shared credentials file
+//
+// Credentials are never combined from several sources, so the customer number of one account is not used with the
+// API key of another. A source that provides only some of the credentials is an error. If no source provides any,
+// empty credentials are returned. A missing shared credentials file is an error only if its path was given
+// explicitly.
+func Resolve(ctx context.Context, sources Sources) (Credentials, error) {
+	explicit := sources.Explicit
+	if explicit.APIPassword == "" && sources.PasswordFile != "" {
+		password, err := ReadPasswordFile(sources.PasswordFile)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("unable to read password file: %w", err)
+		}
+		explicit.APIPassword = password
+	}
+	if explicit != (Credentials{}) {
+		return explicit.complete("the configuration and environment")
+	}
+
+	if len(sources.Command) > 0 {
+		fromCommand, err := FromCommand(ctx, sources.Command)
+		if err != nil {
+			return Credentials{}, err
+		}
+		if fromCommand != (Credentials{}) {
+			return fromCommand.complete("credentials command " + sources.Command[0])
+		}
+	}
+
+	path := sources.File
+	explicitFile := path != ""
+	if !explicitFile {
+		var err error
+		if path, err = DefaultFile(); err != nil {
+			return Credentials{}, nil
+		}
+	}
+
+	profile := sources.Profile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	fromFile, err := LoadFile(path, profile)
+	if errors.Is(err, os.ErrNotExist) && !explicitFile {
+		return Credentials{}, nil
+	}
+	if err != nil {
+		return Credentials{}, err
+	}
+	return fromFile.complete(fmt.Sprintf("profile %q of credentials file %s", profile, path))
+}
+
+// DefaultFile returns the path of the shared credentials file, ~/.netcup/credentials.
+func DefaultFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".netcup", "credentials"), nil
+}
+
+// LoadFile reads a profile from a shared credentials file. The file is either an INI file with one section per
+// profile:
+//
+//	[default]
+//	customer_number = 123456
+//	api_key         = xxxyyyzzz
+//	api_password    = secret
+//
+// or a JSON object with one object per profile, e.g. {"default": {"customer_number": "123456", ...}}.
+func LoadFile(path string, profile string) (Credentials, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var profiles map[string]Credentials
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &profiles); err != nil {
+			return Credentials{}, fmt.Errorf("invalid credentials file %s: %w", path, err)
+		}
+	} else {
+		profiles, err = parseINI(content)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("invalid credentials file %s: %w", path, err)
+		}
+	}
+
+	credentials, ok := profiles[profile]
+	if !ok {
+		return Credentials{}, fmt.Errorf("profile %q not found in credentials file %s", profile, path)
+	}
+	return credentials, nil
+}
+
+func parseINI(content []byte) (map[string]Credentials, error) {
+	profiles := map[string]Credentials{}
+	profile := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profile = strings.TrimSpace(line[1 : len(line)-1])
+			profiles[profile] = Credentials{}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if profile == "" {
+			return nil, fmt.Errorf("line %d: key outside of a [profile] section", lineNumber)
+		}
+
+		credentials := profiles[profile]
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch key {
+		case "customer_number":
+			credentials.CustomerNumber = value
+		case "api_key":
+			credentials.APIKey = value
+		case "api_password":
+			credentials.APIPassword = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", lineNumber, key)
+		}
+		profiles[profile] = credentials
+	}
+
+	return profiles, scanner.Err()
+}
+
+// FromCommand runs an external credential helper and reads the credentials as a JSON object from its standard
+// output. The first element of command is the program, the remaining ones are its arguments. No shell is involved.
+func FromCommand(ctx context.Context, command []string) (Credentials, error) {
+	if len(command) == 0 {
+		return Credentials{}, errors.New("credentials command must not be empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return Credentials{}, fmt.Errorf("credentials command %s failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	credentials := Credentials{}
+	if err := json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+		// the output is not included in the error, it may contain secrets
+		return Credentials{}, fmt.Errorf("credentials command %s did not print a JSON object: %w", command[0], err)
+	}
+	return credentials, nil
+}
+
+// ReadPasswordFile reads an API password from a file, ignoring a trailing newline.
+func ReadPasswordFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
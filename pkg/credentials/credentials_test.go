@@ -0,0 +1,153 @@
+package credentials
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func writeTempFile(content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		panic(err)
+	}
+	path := filepath.Join(dir, "credentials")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		panic(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadFile(t *testing.T) {
+	Convey("reads profiles from an INI file", t, func() {
+		path, cleanUp := writeTempFile(`
+# netcup accounts
+[default]
+customer_number = 12345
+api_key         = KEY
+api_password    = PASS=WORD
+
+[agency]
+customer_number = 67890
+api_key = AGENCY_KEY
+`)
+		defer cleanUp()
+
+		credentials, err := LoadFile(path, DefaultProfile)
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "PASS=WORD"})
+
+		credentials, err = LoadFile(path, "agency")
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "67890", APIKey: "AGENCY_KEY"})
+
+		_, err = LoadFile(path, "missing")
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldContainSubstring, `profile "missing" not found`)
+	})
+
+	Convey("reads profiles from a JSON file", t, func() {
+		path, cleanUp := writeTempFile(`{"default": {"customer_number": "12345", "api_key": "KEY", "api_password": "PASSWORD"}}`)
+		defer cleanUp()
+
+		credentials, err := LoadFile(path, DefaultProfile)
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "PASSWORD"})
+	})
+
+	Convey("rejects unknown keys", t, func() {
+		path, cleanUp := writeTempFile("[default]\npassword = secret\n")
+		defer cleanUp()
+
+		_, err := LoadFile(path, DefaultProfile)
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldContainSubstring, `line 2: unknown key "password"`)
+	})
+}
+
+func TestFromCommand(t *testing.T) {
+	Convey("reads credentials from the output of a command", t, func() {
+		credentials, err := FromCommand(context.Background(), []string{"echo", `{"customer_number": "12345", "api_key": "KEY", "api_password": "PASSWORD"}`})
+
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "PASSWORD"})
+	})
+
+	Convey("fails if the command fails", t, func() {
+		_, err := FromCommand(context.Background(), []string{"false"})
+
+		So(err, ShouldBeError)
+	})
+
+	Convey("does not leak invalid output", t, func() {
+		_, err := FromCommand(context.Background(), []string{"echo", "secret"})
+
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldNotContainSubstring, "secret")
+	})
+}
+
+func TestResolve(t *testing.T) {
+	file, cleanUp := writeTempFile("[default]\ncustomer_number = 67890\napi_key = FILE_KEY\napi_password = FILE_PASSWORD\n" +
+		"[partial]\ncustomer_number = 67890\n")
+	defer cleanUp()
+	command := []string{"echo", `{"customer_number": "13579", "api_key": "COMMAND_KEY", "api_password": "COMMAND_PASSWORD"}`}
+
+	Convey("takes all credentials from the first source that provides any", t, func() {
+		credentials, err := Resolve(context.Background(), Sources{Command: command, File: file})
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "13579", APIKey: "COMMAND_KEY", APIPassword: "COMMAND_PASSWORD"})
+
+		credentials, err = Resolve(context.Background(), Sources{File: file})
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "67890", APIKey: "FILE_KEY", APIPassword: "FILE_PASSWORD"})
+	})
+
+	Convey("does not combine credentials of several sources", t, func() {
+		_, err := Resolve(context.Background(), Sources{Explicit: Credentials{CustomerNumber: "12345"}, Command: command, File: file})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "API key, API password missing")
+
+		_, err = Resolve(context.Background(), Sources{File: file, Profile: "partial"})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, `profile "partial"`)
+	})
+
+	Convey("reads the explicit API password from the password file", t, func() {
+		passwordFile, cleanUp := writeTempFile("secret\n")
+		defer cleanUp()
+
+		credentials, err := Resolve(context.Background(), Sources{
+			Explicit:     Credentials{CustomerNumber: "12345", APIKey: "KEY"},
+			PasswordFile: passwordFile,
+			File:         file,
+		})
+		So(err, ShouldBeNil)
+		So(credentials, ShouldResemble, Credentials{CustomerNumber: "12345", APIKey: "KEY", APIPassword: "secret"})
+	})
+
+	Convey("returns empty credentials without sources", t, func() {
+		// the default credentials file does not exist in the temporary directory
+		defer os.Setenv("HOME", os.Getenv("HOME"))
+		os.Setenv("HOME", filepath.Dir(file))
+
+		credentials, err := Resolve(context.Background(), Sources{})
+		So(err, ShouldBeNil)
+		So(credentials.Complete(), ShouldBeFalse)
+	})
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	Convey("ignores a trailing newline", t, func() {
+		path, cleanUp := writeTempFile("secret\n")
+		defer cleanUp()
+
+		password, err := ReadPasswordFile(path)
+		So(err, ShouldBeNil)
+		So(password, ShouldEqual, "secret")
+	})
+}